@@ -11,8 +11,12 @@ const (
 	NodeCapture
 	NodeAssertion
 	NodeLookaround
-	NodeCharClass   // [new]
+	NodeCharClass // [new]
 	NodeBackreference
+	NodeScriptRun
+	NodeAbsent
+	NodeBalance
+	NodeConditional
 )
 
 // Node is the base interface for AST nodes.
@@ -54,9 +58,10 @@ func (n *Quantifier) Type() NodeType { return NodeQuantifier }
 
 // Capture creates a capture group.
 type Capture struct {
-	Body  Node
-	Index int    // 1-based index
-	Name  string // Optional name
+	Body    Node
+	Index   int    // 1-based index
+	Name    string // Optional name
+	Stacked bool   // Keep every capture on a stack, for balancing groups
 }
 
 func (n *Capture) Type() NodeType { return NodeCapture }
@@ -77,15 +82,17 @@ const (
 type Assertion struct {
 	Kind      AssertionType
 	Multiline bool // True if ^ or $ should behave in multiline mode
+	Unicode   bool // True if \b and \B use Unicode word characters
 }
 
 func (n *Assertion) Type() NodeType { return NodeAssertion }
 
 // Lookaround is a zero-width assertion that matches a pattern.
 type Lookaround struct {
-	Body     Node
-	Negative bool // True for (?!...) and (?<!...)
-	Behind   bool // True for (?<=...) and (?<!...)
+	Body      Node
+	Negative  bool // True for (?!...) and (?<!...)
+	Behind    bool // True for (?<=...) and (?<!...)
+	NonAtomic bool // True for (?*...) and (?<*...); later failures can backtrack into the body
 }
 
 func (n *Lookaround) Type() NodeType { return NodeLookaround }
@@ -105,7 +112,78 @@ func (n *CharClass) Type() NodeType { return NodeCharClass }
 
 // Backreference refers to a previously captured group.
 type Backreference struct {
-	Index int // 1-based index of the capture group
+	Index  int // 1-based index of the capture group
+	Offset int // Position of the reference in the pattern
 }
 
 func (n *Backreference) Type() NodeType { return NodeBackreference }
+
+// ScriptRun requires every character matched by its body to come from the
+// same Unicode script.
+type ScriptRun struct {
+	Body   Node
+	Atomic bool // True for (*atomic_script_run:...)
+}
+
+func (n *ScriptRun) Type() NodeType { return NodeScriptRun }
+
+// Absent matches the longest string that does not contain a match of Body
+// (the Onigmo absent operator).
+type Absent struct {
+	Body Node
+}
+
+func (n *Absent) Type() NodeType { return NodeAbsent }
+
+// Balance is a .NET balancing group, (?<name-pop>...) or (?<-pop>...). When
+// Body matches, the most recent capture of group Pop is removed, and group
+// Index (if non-zero) captures the text between that capture and Body.
+type Balance struct {
+	Body  Node
+	Index int // Group receiving the text in between, 0 for none
+	Pop   int // Group whose most recent capture is removed
+}
+
+func (n *Balance) Type() NodeType { return NodeBalance }
+
+// Conditional matches Yes if group Group holds a capture, and No otherwise.
+type Conditional struct {
+	Group int
+	Yes   Node
+	No    Node // nil matches the empty string
+}
+
+func (n *Conditional) Type() NodeType { return NodeConditional }
+
+// walkNode calls fn for node and each of its descendants, parents first.
+func walkNode(node Node, fn func(Node)) {
+	if node == nil {
+		return
+	}
+	fn(node)
+	switch n := node.(type) {
+	case *Concat:
+		for _, sub := range n.Nodes {
+			walkNode(sub, fn)
+		}
+	case *Alternate:
+		for _, sub := range n.Nodes {
+			walkNode(sub, fn)
+		}
+	case *Quantifier:
+		walkNode(n.Body, fn)
+	case *Capture:
+		walkNode(n.Body, fn)
+	case *Lookaround:
+		walkNode(n.Body, fn)
+	case *ScriptRun:
+		walkNode(n.Body, fn)
+	case *Absent:
+		walkNode(n.Body, fn)
+	case *Balance:
+		walkNode(n.Body, fn)
+	case *Conditional:
+		walkNode(n.Yes, fn)
+		walkNode(n.No, fn)
+	}
+}
@@ -1,5 +1,7 @@
 package gore
 
+import "context"
+
 // Find returns a slice holding the text of the leftmost match in b of the regular expression.
 // A return value of nil indicates no match.
 func (re *Regexp) Find(b []byte) []byte {
@@ -81,3 +83,15 @@ func (re *Regexp) FindAllSubmatch(b []byte, n int) [][][]byte {
 func (re *Regexp) Match(b []byte) bool {
 	return re.MatchString(string(b))
 }
+
+// MatchErr is like Match but returns an error wrapping ErrMatchLimit if the
+// search exceeds the limits.
+func (re *Regexp) MatchErr(b []byte) (bool, error) {
+	return re.MatchStringErr(string(b))
+}
+
+// MatchContext is like MatchErr but also stops, returning ctx.Err(), once
+// ctx is done.
+func (re *Regexp) MatchContext(ctx context.Context, b []byte) (bool, error) {
+	return re.MatchStringContext(ctx, string(b))
+}
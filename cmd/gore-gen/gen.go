@@ -0,0 +1,601 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jackofallops/gore"
+)
+
+// The generated matcher is a backtracker over the program written out as
+// straight-line Go: each instruction becomes a block that does what the VM
+// would do for it, with its operands as constants, and jumps become gotos. Only regular
+// programs are supported, so whether the program matches from an
+// instruction at a position never depends on how the search got there, and
+// like gore's memoization the matcher remembers the states it has tried for
+// every instruction with more than one way in. The set is kept across start
+// positions, which bounds a search by the input times the program size.
+
+// inlineRanges is the most ranges a class may have to be tested inline;
+// larger ones get a table.
+const inlineRanges = 4
+
+// generate returns the Go source of the matchers for patterns, in package
+// pkg.
+func generate(pkg string, patterns []pattern) ([]byte, error) {
+	g := &generator{}
+	g.printf("// Code generated by gore-gen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", pkg)
+
+	var body generator
+	for _, p := range patterns {
+		re, err := gore.Compile(p.expr)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", p.line, err)
+		}
+		if err := body.pattern(p, re); err != nil {
+			return nil, fmt.Errorf("line %d: %v", p.line, err)
+		}
+	}
+	body.helpers()
+
+	g.printf("import (\n")
+	if body.usesStrings {
+		g.printf("%q\n", "strings")
+	}
+	if body.usesUnicode {
+		g.printf("%q\n", "unicode")
+	}
+	g.printf("%q\n", "unicode/utf8")
+	g.printf(")\n\n")
+	g.Write(body.Bytes())
+
+	src, err := format.Source(g.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v", err)
+	}
+	return src, nil
+}
+
+// generator accumulates generated code.
+type generator struct {
+	bytes.Buffer
+	usesStrings, usesUnicode, usesSets, usesWord, usesUnicodeWord bool
+
+	matcher string       // Matcher being written
+	labels  []bool       // Its instructions that need a label
+	sets    bytes.Buffer // Tables of its large classes
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(g, format, args...)
+}
+
+// pattern writes the exported functions for p and the matcher behind them.
+func (g *generator) pattern(p pattern, re *gore.Regexp) error {
+	prog := re.Prog()
+	for _, inst := range prog.Insts {
+		switch inst.Op {
+		case gore.OpMatch, gore.OpChar, gore.OpCharClass, gore.OpAny, gore.OpJmp, gore.OpSplit, gore.OpSave, gore.OpAssert:
+		default:
+			return fmt.Errorf("pattern needs the backtracking engine: %s", p.expr)
+		}
+	}
+
+	name, exec := p.name, "goreGenExec"+p.name
+	regs, groups := prog.NumRegs, re.NumSubexp()+1
+	doc := "//\n//\t" + p.expr + "\n"
+
+	g.printf("// %sMatchString reports whether s contains a match of\n%s", name, doc)
+	g.printf("func %sMatchString(s string) bool {\n", name)
+	g.printf("var caps [%d]int\n", regs)
+	g.printf("return %s(s, caps[:])\n}\n\n", exec)
+
+	g.printf("// %sFindString returns the text of the leftmost match in s of\n%s", name, doc)
+	g.printf("// or \"\" if there is none.\n")
+	g.printf("func %sFindString(s string) string {\n", name)
+	g.printf("var caps [%d]int\n", regs)
+	g.printf("if !%s(s, caps[:]) {\nreturn \"\"\n}\n", exec)
+	g.printf("return s[caps[0]:caps[1]]\n}\n\n")
+
+	g.printf("// %sFindStringIndex returns the start and end of the leftmost match in s\n// of\n%s", name, doc)
+	g.printf("// or nil if there is none.\n")
+	g.printf("func %sFindStringIndex(s string) []int {\n", name)
+	g.printf("var caps [%d]int\n", regs)
+	g.printf("if !%s(s, caps[:]) {\nreturn nil\n}\n", exec)
+	g.printf("return []int{caps[0], caps[1]}\n}\n\n")
+
+	g.printf("// %sFindStringSubmatch returns the text of the leftmost match in s of\n%s", name, doc)
+	g.printf("// and of its groups, with \"\" for groups that did not take part, or nil\n// if there is no match.\n")
+	g.printf("func %sFindStringSubmatch(s string) []string {\n", name)
+	g.printf("var caps [%d]int\n", regs)
+	g.printf("if !%s(s, caps[:]) {\nreturn nil\n}\n", exec)
+	g.printf("return goreGenSubmatches(s, caps[:%d])\n}\n\n", 2*groups)
+
+	g.exec(exec, prog)
+	if g.sets.Len() > 0 {
+		g.printf("var (\n")
+		g.Write(g.sets.Bytes())
+		g.printf(")\n\n")
+		g.sets.Reset()
+	}
+	return nil
+}
+
+// exec writes the matcher for prog, which sets caps to the registers of the
+// leftmost match and reports whether there is one.
+func (g *generator) exec(exec string, prog *gore.Prog) {
+	insts := prog.Insts
+	live := liveInsts(prog)
+	slots, nslots := visitSlots(prog, live)
+	g.matcher = exec
+
+	// Go rejects unused labels, so only the instructions reached by a goto
+	// get one: the start, jump targets other than the next instruction, and
+	// the second way of each split, which the stack goes back to
+	g.labels = make([]bool, len(insts))
+	g.labels[prog.Start] = true
+	var resumes []int
+	for pc, inst := range insts {
+		if !live[pc] {
+			continue
+		}
+		switch inst.Op {
+		case gore.OpJmp:
+			g.label(pc, inst.Out)
+		case gore.OpSplit:
+			g.label(pc, inst.Out)
+			if inst.Out1 < len(insts) && !slices.Contains(resumes, inst.Out1) {
+				g.labels[inst.Out1] = true
+				resumes = append(resumes, inst.Out1)
+			}
+		}
+	}
+	slices.Sort(resumes)
+
+	g.printf("func %s(s string, caps []int) bool {\n", exec)
+	g.printf("for i := range caps {\ncaps[i] = -1\n}\n")
+	if nslots > 0 {
+		g.printf("visited := make([]uint64, min((%d*(len(s)+1)+63)/64, goreGenVisitBudget/8))\n", nslots)
+	}
+	g.printf("stack := make([]goreGenJob, 0, 16)\n")
+	g.printf("start, pos := 0, 0\n")
+
+	// Try the next start, or give up
+	anchored := anchored(prog)
+	if !anchored {
+		g.printf("next:\n")
+	}
+	if prog.Prefix != "" {
+		g.usesStrings = true
+		g.printf("if start < len(s) {\n")
+		g.printf("i := strings.Index(s[start:], %q)\n", prog.Prefix)
+		g.printf("if i < 0 {\nreturn false\n}\n")
+		g.printf("start += i\n}\n")
+	}
+	g.printf("pos = start\n")
+	g.printf("goto L%d\n", prog.Start)
+	g.printf("fail:\n")
+	g.printf("if len(stack) == 0 {\n")
+	if anchored {
+		g.printf("return false\n")
+	} else {
+		g.printf("if start == len(s) {\nreturn false\n}\n")
+		g.printf("_, w := goreGenStep(s, start)\n")
+		g.printf("start += w\n")
+		g.printf("goto next\n")
+	}
+	g.printf("}\n")
+
+	// Go back to the most recent choice, undoing the registers set since
+	g.printf("{\n")
+	g.printf("job := stack[len(stack)-1]\n")
+	g.printf("stack = stack[:len(stack)-1]\n")
+	if len(resumes) == 0 {
+		g.printf("caps[job.reg] = job.pos\n")
+	} else {
+		g.printf("if job.reg >= 0 {\ncaps[job.reg] = job.pos\ngoto fail\n}\n")
+		g.printf("pos = job.pos\n")
+		g.printf("switch job.pc {\n")
+		for _, pc := range resumes {
+			g.printf("case %d:\ngoto L%d\n", pc, pc)
+		}
+		g.printf("}\n")
+	}
+	g.printf("goto fail\n")
+	g.printf("}\n")
+
+	reachable := false
+	for pc := range insts {
+		if !reachable && !g.labels[pc] {
+			continue
+		}
+		if g.labels[pc] {
+			g.printf("L%d:\n", pc)
+		}
+		g.printf("// %s\n{\n", insts[pc])
+		if slots[pc] >= 0 {
+			g.printf("if !goreGenVisit(visited, pos*%d+%d) {\ngoto fail\n}\n", nslots, slots[pc])
+		}
+		reachable = g.inst(prog, pc)
+		g.printf("}\n")
+	}
+	if reachable {
+		g.printf("goto fail\n")
+	}
+	g.printf("}\n\n")
+}
+
+// label marks target as reached by a goto from pc, unless pc goes on to it
+// or it is past the end.
+func (g *generator) label(pc, target int) {
+	if target != pc+1 && target < len(g.labels) {
+		g.labels[target] = true
+	}
+}
+
+// jump writes the way from pc to target, and reports whether it falls
+// through to the next instruction.
+func (g *generator) jump(pc, target int) bool {
+	switch {
+	case target >= len(g.labels):
+		g.printf("goto fail\n")
+		return false
+	case target == pc+1:
+		return true
+	}
+	g.printf("goto L%d\n", target)
+	return false
+}
+
+// anchored reports whether prog can only match at the start of the input,
+// so that the matcher need not try anywhere else.
+func anchored(prog *gore.Prog) bool {
+	for pc, steps := prog.Start, 0; pc < len(prog.Insts) && steps < len(prog.Insts); steps++ {
+		inst := &prog.Insts[pc]
+		switch inst.Op {
+		case gore.OpSave:
+			pc++
+		case gore.OpJmp:
+			pc = inst.Out
+		case gore.OpAssert:
+			if inst.Assert == gore.AssertStringStart || inst.Assert == gore.AssertStartText && !inst.Multiline {
+				return true
+			}
+			pc++
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// inst writes the code for the instruction at pc, and reports whether it
+// falls through to the next instruction.
+func (g *generator) inst(prog *gore.Prog, pc int) bool {
+	inst := &prog.Insts[pc]
+	switch inst.Op {
+	case gore.OpMatch:
+		g.printf("return true\n")
+		return false
+
+	case gore.OpJmp:
+		return g.jump(pc, inst.Out)
+
+	case gore.OpSplit:
+		if inst.Out1 < len(prog.Insts) {
+			g.printf("stack = append(stack, goreGenJob{pc: %d, pos: pos, reg: -1})\n", inst.Out1)
+		}
+		return g.jump(pc, inst.Out)
+
+	case gore.OpSave:
+		g.printf("stack = append(stack, goreGenJob{pos: caps[%d], reg: %d})\n", inst.Idx, inst.Idx)
+		g.printf("caps[%d] = pos\n", inst.Idx)
+
+	case gore.OpAssert:
+		g.printf("if !(%s) {\ngoto fail\n}\n", g.assertion(inst))
+
+	case gore.OpChar, gore.OpCharClass, gore.OpAny:
+		set := inst.RuneSet()
+		if len(set) == 0 {
+			g.printf("goto fail\n")
+			return false
+		}
+		g.printf("if pos >= len(s) {\ngoto fail\n}\n")
+		if set[len(set)-1].Hi < utf8.RuneSelf {
+			// Only ASCII, which is always a single byte
+			g.printf("if c := s[pos]; %s {\ngoto fail\n}\n", notIn("c", set, rangeTest("c", set)))
+			g.printf("pos++\n")
+		} else {
+			g.printf("r, w := goreGenStep(s, pos)\n")
+			g.printf("if %s {\ngoto fail\n}\n", notIn("r", set, g.classTest(pc, set)))
+			g.printf("pos += w\n")
+		}
+	}
+	return true
+}
+
+// assertion returns the condition under which inst holds at pos. It follows
+// gore's VM, for which a NUL byte reads as the end of the input.
+func (g *generator) assertion(inst *gore.Inst) string {
+	switch inst.Assert {
+	case gore.AssertStartText:
+		if inst.Multiline {
+			return `pos == 0 || s[pos-1] == '\n'`
+		}
+		return "pos == 0"
+	case gore.AssertEndText:
+		if inst.Multiline {
+			return `pos >= len(s) || s[pos] == 0 || s[pos] == '\n'`
+		}
+		return "pos >= len(s) || s[pos] == 0"
+	case gore.AssertStringStart:
+		return "pos == 0"
+	case gore.AssertStringEnd:
+		return `pos >= len(s) || s[pos] == 0 || s[pos] == '\n' && (pos+1 >= len(s) || s[pos+1] == 0)`
+	case gore.AssertAbsoluteEnd:
+		return "pos >= len(s) || s[pos] == 0"
+	case gore.AssertWordBoundary, gore.AssertNotWordBoundary:
+		test := "goreGenWordBoundary(s, pos)"
+		if inst.Unicode {
+			test = "goreGenUnicodeWordBoundary(s, pos)"
+			g.usesUnicode, g.usesUnicodeWord = true, true
+		} else {
+			g.usesWord = true
+		}
+		if inst.Assert == gore.AssertNotWordBoundary {
+			return "!" + test
+		}
+		return test
+	}
+	return "true"
+}
+
+// classTest returns the condition under which the rune r is in set, the
+// class at pc, adding a table for sets too large to test inline.
+func (g *generator) classTest(pc int, set []gore.RuneRange) string {
+	if len(set) <= inlineRanges {
+		return rangeTest("r", set)
+	}
+	g.usesSets = true
+	var ascii [2]uint64
+	var ranges []string
+	for _, rng := range set {
+		for r := rng.Lo; r <= rng.Hi && r < utf8.RuneSelf; r++ {
+			ascii[r/64] |= 1 << (r % 64)
+		}
+		if rng.Hi >= utf8.RuneSelf {
+			ranges = append(ranges, fmt.Sprintf("%#x, %#x", max(rng.Lo, utf8.RuneSelf), rng.Hi))
+		}
+	}
+	name := fmt.Sprintf("%sSet%d", g.matcher, pc)
+	fmt.Fprintf(&g.sets, "%s = goreGenSet{ascii: [2]uint64{%#x, %#x}, ranges: []rune{%s}}\n",
+		name, ascii[0], ascii[1], strings.Join(ranges, ", "))
+	return name + ".has(r)"
+}
+
+// notIn returns the condition under which v is not in set, given test, the
+// condition under which it is.
+func notIn(v string, set []gore.RuneRange, test string) string {
+	if len(set) == 1 && set[0].Lo == set[0].Hi {
+		return fmt.Sprintf("%s != %s", v, literalRune(set[0].Lo))
+	}
+	return "!(" + test + ")"
+}
+
+// rangeTest returns the condition under which v is in set.
+func rangeTest(v string, set []gore.RuneRange) string {
+	var terms []string
+	for _, rng := range set {
+		switch {
+		case rng.Lo == rng.Hi:
+			terms = append(terms, fmt.Sprintf("%s == %s", v, literalRune(rng.Lo)))
+		case rng.Lo == 0:
+			terms = append(terms, fmt.Sprintf("%s <= %s", v, literalRune(rng.Hi)))
+		case rng.Hi == unicode.MaxRune:
+			terms = append(terms, fmt.Sprintf("%s >= %s", v, literalRune(rng.Lo)))
+		default:
+			terms = append(terms, fmt.Sprintf("%s >= %s && %s <= %s", v, literalRune(rng.Lo), v, literalRune(rng.Hi)))
+		}
+	}
+	if len(terms) == 0 {
+		return "false"
+	}
+	return strings.Join(terms, " || ")
+}
+
+// literalRune writes r as a Go rune literal, which for ASCII also compares
+// with a byte.
+func literalRune(r rune) string {
+	return strconv.QuoteRuneToASCII(r)
+}
+
+// visitSlots numbers the instructions with more than one way in, which are
+// the states the matcher remembers. It returns the number of each, -1 for
+// the others, and how many there are.
+func visitSlots(prog *gore.Prog, live []bool) ([]int, int) {
+	insts := prog.Insts
+	indegree := make([]int, len(insts))
+	indegree[prog.Start]++
+	for pc := range insts {
+		if !live[pc] {
+			continue
+		}
+		for _, n := range successors(prog, pc) {
+			indegree[n]++
+		}
+	}
+
+	slots := make([]int, len(insts))
+	n := 0
+	for pc := range insts {
+		slots[pc] = -1
+		if indegree[pc] > 1 {
+			slots[pc] = n
+			n++
+		}
+	}
+	return slots, n
+}
+
+// successors returns the instructions that can run after the one at pc.
+func successors(prog *gore.Prog, pc int) []int {
+	inst := &prog.Insts[pc]
+	var next []int
+	switch inst.Op {
+	case gore.OpMatch:
+	case gore.OpJmp:
+		next = []int{inst.Out}
+	case gore.OpSplit:
+		next = []int{inst.Out, inst.Out1}
+	default:
+		next = []int{pc + 1}
+	}
+	return slices.DeleteFunc(next, func(n int) bool { return n >= len(prog.Insts) })
+}
+
+// liveInsts reports which instructions of prog can run. The copies of loop
+// bodies that can match empty leave instructions that nothing reaches.
+func liveInsts(prog *gore.Prog) []bool {
+	live := make([]bool, len(prog.Insts))
+	stack := []int{prog.Start}
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if live[pc] {
+			continue
+		}
+		live[pc] = true
+		stack = append(stack, successors(prog, pc)...)
+	}
+	return live
+}
+
+// helpers writes the declarations the matchers share.
+func (g *generator) helpers() {
+	g.printf(`// goreGenVisitBudget is the memory the visited set of one call may take,
+// as for gore's DefaultMemoBudget. States at positions past what it covers
+// are not remembered.
+const goreGenVisitBudget = %d << 20
+
+`, gore.DefaultMemoBudget>>20)
+	g.printf(`// goreGenJob is an entry of a matcher's backtracking stack: a state to
+// try, or, if reg is not negative, a register to restore to pos.
+type goreGenJob struct {
+	pc, pos, reg int
+}
+
+// goreGenStep decodes the rune at pos, which must be inside s.
+func goreGenStep(s string, pos int) (rune, int) {
+	if c := s[pos]; c < utf8.RuneSelf {
+		return rune(c), 1
+	}
+	return utf8.DecodeRuneInString(s[pos:])
+}
+
+// goreGenVisit records state i in visited, and reports whether it is the
+// first time, or past what visited covers.
+func goreGenVisit(visited []uint64, i int) bool {
+	word, bit := i/64, uint64(1)<<(i%%64)
+	if word >= len(visited) {
+		return true
+	}
+	if visited[word]&bit != 0 {
+		return false
+	}
+	visited[word] |= bit
+	return true
+}
+
+// goreGenSubmatches returns the text of each group in caps.
+func goreGenSubmatches(s string, caps []int) []string {
+	result := make([]string, len(caps)/2)
+	for i := range result {
+		if start, end := caps[2*i], caps[2*i+1]; start >= 0 && end >= start {
+			result[i] = s[start:end]
+		}
+	}
+	return result
+}
+`)
+
+	if g.usesSets {
+		g.printf(`
+// goreGenSet is a large character class: a bitmap of its ASCII runes, and
+// the rest as sorted pairs of bounds.
+type goreGenSet struct {
+	ascii  [2]uint64
+	ranges []rune
+}
+
+func (set *goreGenSet) has(r rune) bool {
+	if r < utf8.RuneSelf {
+		return set.ascii[r/64]>>(r%%64)&1 != 0
+	}
+	lo, hi := 0, len(set.ranges)/2
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		switch {
+		case r < set.ranges[2*m]:
+			hi = m
+		case r > set.ranges[2*m+1]:
+			lo = m + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+`)
+	}
+
+	if g.usesWord {
+		g.printf(`
+// goreGenWordBoundary reports whether pos is between a word character and
+// something else. Word characters are ASCII, so bytes will do.
+func goreGenWordBoundary(s string, pos int) bool {
+	before := pos > 0 && goreGenIsWord(rune(s[pos-1]))
+	after := pos < len(s) && goreGenIsWord(rune(s[pos]))
+	return before != after
+}
+`)
+	}
+	if g.usesUnicodeWord {
+		g.printf(`
+// goreGenUnicodeWordBoundary is goreGenWordBoundary for Unicode word
+// characters.
+func goreGenUnicodeWordBoundary(s string, pos int) bool {
+	before, after := false, false
+	if pos > 0 {
+		r, _ := utf8.DecodeLastRuneInString(s[:pos])
+		before = goreGenIsUnicodeWord(r)
+	}
+	if pos < len(s) {
+		r, _ := utf8.DecodeRuneInString(s[pos:])
+		after = goreGenIsUnicodeWord(r)
+	}
+	return before != after
+}
+
+func goreGenIsUnicodeWord(r rune) bool {
+	if r < utf8.RuneSelf {
+		return goreGenIsWord(r)
+	}
+	return unicode.In(r, unicode.L, unicode.N, unicode.Mn, unicode.Pc)
+}
+`)
+	}
+	if g.usesWord || g.usesUnicodeWord {
+		g.printf(`
+func goreGenIsWord(r rune) bool {
+	return r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_'
+}
+`)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedUpToDate tests that the matchers checked in under
+// internal/generated are what gore-gen writes now
+func TestGeneratedUpToDate(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "patterns_gore.go")
+	if err := run("internal/generated/patterns.go", out); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("internal/generated/patterns_gore.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("internal/generated/patterns_gore.go is out of date; run go generate ./cmd/gore-gen/...")
+	}
+}
+
+// TestReadAnnotations tests that patterns are read verbatim up to the end of
+// the line
+func TestReadAnnotations(t *testing.T) {
+	in := filepath.Join(t.TempDir(), "p.go")
+	src := "package p\n\n\t//gore:pattern Spaced a b  \n"
+	if err := os.WriteFile(in, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, patterns, err := readAnnotations(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patterns) != 1 || patterns[0].expr != "a b  " {
+		t.Errorf("readAnnotations(%q) = %+v; want the pattern %q", src, patterns, "a b  ")
+	}
+}
+
+// TestGenerateErrors tests the annotations gore-gen rejects
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"package p\n", "no //gore:pattern annotations"},
+		{"package p\n\n//gore:pattern lower a+\n", "exported Name"},
+		{"package p\n\n//gore:pattern Missing\n", "exported Name"},
+		{"package p\n\n//gore:pattern Bad a(\n", "line 3"},
+		{"package p\n\n//gore:pattern Dup a\n//gore:pattern Dup b\n", "p.go:4: Dup already annotated at line 3"},
+		{"package p\n\n//gore:pattern Backref (a)\\1\n", "needs the backtracking engine"},
+		{"package p\n\n//gore:pattern Look a(?=b)\n", "needs the backtracking engine"},
+	}
+	dir := t.TempDir()
+	for _, tc := range tests {
+		in := filepath.Join(dir, "p.go")
+		if err := os.WriteFile(in, []byte(tc.src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		err := run(in, filepath.Join(dir, "p_gore.go"))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("run(%q) = %v; want error containing %q", tc.src, err, tc.want)
+		}
+	}
+}
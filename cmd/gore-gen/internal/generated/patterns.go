@@ -0,0 +1,21 @@
+// Package generated holds matchers written by gore-gen, to check them
+// against gore's own engines.
+package generated
+
+//go:generate go run github.com/jackofallops/gore/cmd/gore-gen
+
+//gore:pattern Date ^(\d{4})-(\d{2})-(\d{2})$
+//gore:pattern Email (?i)([\w.+-]+)@([\w-]+)\.(com|org)
+//gore:pattern Request (GET|POST|PUT) (/\S*)(?: HTTP/(\d\.\d))?
+//gore:pattern Words (?i)\b(k\w*)\b
+//gore:pattern UnicodeWords (*UCP)\b\w+\b
+//gore:pattern Lines (?m)^(\w+):\s*(.*)$
+//gore:pattern Tail \w+\Z
+//gore:pattern End x*\z
+//gore:pattern Nested ((a|ab)*)c
+//gore:pattern Lazy <(.+?)>
+//gore:pattern Optional (a)?(b)?(c)?
+//gore:pattern Greek [^ -~]+|[αβγ]
+//gore:pattern Prefix foo(bar|baz)+
+//gore:pattern Empty (a|b?)+c|(x*)*$
+//gore:pattern UUID ([0-9a-f]{8})-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-([0-9a-f]{12})
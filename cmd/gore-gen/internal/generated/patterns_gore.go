@@ -0,0 +1,3494 @@
+// Code generated by gore-gen. DO NOT EDIT.
+
+package generated
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DateMatchString reports whether s contains a match of
+//
+//	^(\d{4})-(\d{2})-(\d{2})$
+func DateMatchString(s string) bool {
+	var caps [8]int
+	return goreGenExecDate(s, caps[:])
+}
+
+// DateFindString returns the text of the leftmost match in s of
+//
+//	^(\d{4})-(\d{2})-(\d{2})$
+//
+// or "" if there is none.
+func DateFindString(s string) string {
+	var caps [8]int
+	if !goreGenExecDate(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// DateFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	^(\d{4})-(\d{2})-(\d{2})$
+//
+// or nil if there is none.
+func DateFindStringIndex(s string) []int {
+	var caps [8]int
+	if !goreGenExecDate(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// DateFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	^(\d{4})-(\d{2})-(\d{2})$
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func DateFindStringSubmatch(s string) []string {
+	var caps [8]int
+	if !goreGenExecDate(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:8])
+}
+
+func goreGenExecDate(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		return false
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		caps[job.reg] = job.pos
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// assert 0
+	{
+		if !(pos == 0) {
+			goto fail
+		}
+	}
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+	// class [{48 57}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9') {
+			goto fail
+		}
+		pos++
+	}
+	// save 3
+	{
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+	// char '-'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '-' {
+			goto fail
+		}
+		pos++
+	}
+	// save 4
+	{
+		stack = append(stack, goreGenJob{pos: caps[4], reg: 4})
+		caps[4] = pos
+	}
+	// class [{48 57}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9') {
+			goto fail
+		}
+		pos++
+	}
+	// save 5
+	{
+		stack = append(stack, goreGenJob{pos: caps[5], reg: 5})
+		caps[5] = pos
+	}
+	// char '-'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '-' {
+			goto fail
+		}
+		pos++
+	}
+	// save 6
+	{
+		stack = append(stack, goreGenJob{pos: caps[6], reg: 6})
+		caps[6] = pos
+	}
+	// class [{48 57}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9') {
+			goto fail
+		}
+		pos++
+	}
+	// save 7
+	{
+		stack = append(stack, goreGenJob{pos: caps[7], reg: 7})
+		caps[7] = pos
+	}
+	// assert 1
+	{
+		if !(pos >= len(s) || s[pos] == 0) {
+			goto fail
+		}
+	}
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// EmailMatchString reports whether s contains a match of
+//
+//	(?i)([\w.+-]+)@([\w-]+)\.(com|org)
+func EmailMatchString(s string) bool {
+	var caps [8]int
+	return goreGenExecEmail(s, caps[:])
+}
+
+// EmailFindString returns the text of the leftmost match in s of
+//
+//	(?i)([\w.+-]+)@([\w-]+)\.(com|org)
+//
+// or "" if there is none.
+func EmailFindString(s string) string {
+	var caps [8]int
+	if !goreGenExecEmail(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// EmailFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	(?i)([\w.+-]+)@([\w-]+)\.(com|org)
+//
+// or nil if there is none.
+func EmailFindStringIndex(s string) []int {
+	var caps [8]int
+	if !goreGenExecEmail(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// EmailFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	(?i)([\w.+-]+)@([\w-]+)\.(com|org)
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func EmailFindStringSubmatch(s string) []string {
+	var caps [8]int
+	if !goreGenExecEmail(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:8])
+}
+
+func goreGenExecEmail(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((3*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 4:
+			goto L4
+		case 9:
+			goto L9
+		case 17:
+			goto L17
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+L2:
+	// class [{43 43} {45 46} {48 57} {65 90} {95 95} {97 122}]
+	{
+		if !goreGenVisit(visited, pos*3+0) {
+			goto fail
+		}
+		if pos >= len(s) {
+			goto fail
+		}
+		r, w := goreGenStep(s, pos)
+		if !(goreGenExecEmailSet2.has(r)) {
+			goto fail
+		}
+		pos += w
+	}
+	// split 2, 4
+	{
+		stack = append(stack, goreGenJob{pc: 4, pos: pos, reg: -1})
+		goto L2
+	}
+L4:
+	// save 3
+	{
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+	// char '@'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '@' {
+			goto fail
+		}
+		pos++
+	}
+	// save 4
+	{
+		stack = append(stack, goreGenJob{pos: caps[4], reg: 4})
+		caps[4] = pos
+	}
+L7:
+	// class [{45 45} {48 57} {65 90} {95 95} {97 122}]
+	{
+		if !goreGenVisit(visited, pos*3+1) {
+			goto fail
+		}
+		if pos >= len(s) {
+			goto fail
+		}
+		r, w := goreGenStep(s, pos)
+		if !(goreGenExecEmailSet7.has(r)) {
+			goto fail
+		}
+		pos += w
+	}
+	// split 7, 9
+	{
+		stack = append(stack, goreGenJob{pc: 9, pos: pos, reg: -1})
+		goto L7
+	}
+L9:
+	// save 5
+	{
+		stack = append(stack, goreGenJob{pos: caps[5], reg: 5})
+		caps[5] = pos
+	}
+	// char '.'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '.' {
+			goto fail
+		}
+		pos++
+	}
+	// save 6
+	{
+		stack = append(stack, goreGenJob{pos: caps[6], reg: 6})
+		caps[6] = pos
+	}
+	// split 13, 17
+	{
+		stack = append(stack, goreGenJob{pc: 17, pos: pos, reg: -1})
+	}
+	// char 'c'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c == 'C' || c == 'c') {
+			goto fail
+		}
+		pos++
+	}
+	// char 'o'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c == 'O' || c == 'o') {
+			goto fail
+		}
+		pos++
+	}
+	// char 'm'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c == 'M' || c == 'm') {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 20
+	{
+		goto L20
+	}
+L17:
+	// char 'o'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c == 'O' || c == 'o') {
+			goto fail
+		}
+		pos++
+	}
+	// char 'r'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c == 'R' || c == 'r') {
+			goto fail
+		}
+		pos++
+	}
+	// char 'g'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c == 'G' || c == 'g') {
+			goto fail
+		}
+		pos++
+	}
+L20:
+	// save 7
+	{
+		if !goreGenVisit(visited, pos*3+2) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[7], reg: 7})
+		caps[7] = pos
+	}
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+var (
+	goreGenExecEmailSet2 = goreGenSet{ascii: [2]uint64{0x3ff680000000000, 0x7fffffe87fffffe}, ranges: []rune{0x17f, 0x17f, 0x212a, 0x212a}}
+	goreGenExecEmailSet7 = goreGenSet{ascii: [2]uint64{0x3ff200000000000, 0x7fffffe87fffffe}, ranges: []rune{0x17f, 0x17f, 0x212a, 0x212a}}
+)
+
+// RequestMatchString reports whether s contains a match of
+//
+//	(GET|POST|PUT) (/\S*)(?: HTTP/(\d\.\d))?
+func RequestMatchString(s string) bool {
+	var caps [8]int
+	return goreGenExecRequest(s, caps[:])
+}
+
+// RequestFindString returns the text of the leftmost match in s of
+//
+//	(GET|POST|PUT) (/\S*)(?: HTTP/(\d\.\d))?
+//
+// or "" if there is none.
+func RequestFindString(s string) string {
+	var caps [8]int
+	if !goreGenExecRequest(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// RequestFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	(GET|POST|PUT) (/\S*)(?: HTTP/(\d\.\d))?
+//
+// or nil if there is none.
+func RequestFindStringIndex(s string) []int {
+	var caps [8]int
+	if !goreGenExecRequest(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// RequestFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	(GET|POST|PUT) (/\S*)(?: HTTP/(\d\.\d))?
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func RequestFindStringSubmatch(s string) []string {
+	var caps [8]int
+	if !goreGenExecRequest(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:8])
+}
+
+func goreGenExecRequest(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((3*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 7:
+			goto L7
+		case 13:
+			goto L13
+		case 23:
+			goto L23
+		case 36:
+			goto L36
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+	// split 3, 7
+	{
+		stack = append(stack, goreGenJob{pc: 7, pos: pos, reg: -1})
+	}
+	// char 'G'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'G' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'E'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'E' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'T'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'T' {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 16
+	{
+		goto L16
+	}
+L7:
+	// split 8, 13
+	{
+		stack = append(stack, goreGenJob{pc: 13, pos: pos, reg: -1})
+	}
+	// char 'P'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'P' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'O'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'O' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'S'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'S' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'T'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'T' {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 16
+	{
+		goto L16
+	}
+L13:
+	// char 'P'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'P' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'U'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'U' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'T'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'T' {
+			goto fail
+		}
+		pos++
+	}
+L16:
+	// save 3
+	{
+		if !goreGenVisit(visited, pos*3+0) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+	// char ' '
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != ' ' {
+			goto fail
+		}
+		pos++
+	}
+	// save 4
+	{
+		stack = append(stack, goreGenJob{pos: caps[4], reg: 4})
+		caps[4] = pos
+	}
+	// char '/'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '/' {
+			goto fail
+		}
+		pos++
+	}
+L20:
+	// split 21, 23
+	{
+		if !goreGenVisit(visited, pos*3+1) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pc: 23, pos: pos, reg: -1})
+	}
+	// class ^[{9 9} {10 10} {13 13} {32 32}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		r, w := goreGenStep(s, pos)
+		if !(r <= '\b' || r >= '\v' && r <= '\f' || r >= '\x0e' && r <= '\x1f' || r >= '!') {
+			goto fail
+		}
+		pos += w
+	}
+	// jmp 20
+	{
+		goto L20
+	}
+L23:
+	// save 5
+	{
+		stack = append(stack, goreGenJob{pos: caps[5], reg: 5})
+		caps[5] = pos
+	}
+	// split 25, 36
+	{
+		stack = append(stack, goreGenJob{pc: 36, pos: pos, reg: -1})
+	}
+	// char ' '
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != ' ' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'H'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'H' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'T'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'T' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'T'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'T' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'P'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'P' {
+			goto fail
+		}
+		pos++
+	}
+	// char '/'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '/' {
+			goto fail
+		}
+		pos++
+	}
+	// save 6
+	{
+		stack = append(stack, goreGenJob{pos: caps[6], reg: 6})
+		caps[6] = pos
+	}
+	// class [{48 57}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9') {
+			goto fail
+		}
+		pos++
+	}
+	// char '.'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '.' {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9') {
+			goto fail
+		}
+		pos++
+	}
+	// save 7
+	{
+		stack = append(stack, goreGenJob{pos: caps[7], reg: 7})
+		caps[7] = pos
+	}
+L36:
+	// save 1
+	{
+		if !goreGenVisit(visited, pos*3+2) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// WordsMatchString reports whether s contains a match of
+//
+//	(?i)\b(k\w*)\b
+func WordsMatchString(s string) bool {
+	var caps [4]int
+	return goreGenExecWords(s, caps[:])
+}
+
+// WordsFindString returns the text of the leftmost match in s of
+//
+//	(?i)\b(k\w*)\b
+//
+// or "" if there is none.
+func WordsFindString(s string) string {
+	var caps [4]int
+	if !goreGenExecWords(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// WordsFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	(?i)\b(k\w*)\b
+//
+// or nil if there is none.
+func WordsFindStringIndex(s string) []int {
+	var caps [4]int
+	if !goreGenExecWords(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// WordsFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	(?i)\b(k\w*)\b
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func WordsFindStringSubmatch(s string) []string {
+	var caps [4]int
+	if !goreGenExecWords(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:4])
+}
+
+func goreGenExecWords(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((1*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 7:
+			goto L7
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// assert 2
+	{
+		if !(goreGenWordBoundary(s, pos)) {
+			goto fail
+		}
+	}
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+	// char 'k'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		r, w := goreGenStep(s, pos)
+		if !(r == 'K' || r == 'k' || r == '\u212a') {
+			goto fail
+		}
+		pos += w
+	}
+L4:
+	// split 5, 7
+	{
+		if !goreGenVisit(visited, pos*1+0) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pc: 7, pos: pos, reg: -1})
+	}
+	// class [{48 57} {65 90} {95 95} {97 122}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		r, w := goreGenStep(s, pos)
+		if !(goreGenExecWordsSet5.has(r)) {
+			goto fail
+		}
+		pos += w
+	}
+	// jmp 4
+	{
+		goto L4
+	}
+L7:
+	// save 3
+	{
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+	// assert 2
+	{
+		if !(goreGenWordBoundary(s, pos)) {
+			goto fail
+		}
+	}
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+var (
+	goreGenExecWordsSet5 = goreGenSet{ascii: [2]uint64{0x3ff000000000000, 0x7fffffe87fffffe}, ranges: []rune{0x17f, 0x17f, 0x212a, 0x212a}}
+)
+
+// UnicodeWordsMatchString reports whether s contains a match of
+//
+//	(*UCP)\b\w+\b
+func UnicodeWordsMatchString(s string) bool {
+	var caps [2]int
+	return goreGenExecUnicodeWords(s, caps[:])
+}
+
+// UnicodeWordsFindString returns the text of the leftmost match in s of
+//
+//	(*UCP)\b\w+\b
+//
+// or "" if there is none.
+func UnicodeWordsFindString(s string) string {
+	var caps [2]int
+	if !goreGenExecUnicodeWords(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// UnicodeWordsFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	(*UCP)\b\w+\b
+//
+// or nil if there is none.
+func UnicodeWordsFindStringIndex(s string) []int {
+	var caps [2]int
+	if !goreGenExecUnicodeWords(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// UnicodeWordsFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	(*UCP)\b\w+\b
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func UnicodeWordsFindStringSubmatch(s string) []string {
+	var caps [2]int
+	if !goreGenExecUnicodeWords(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:2])
+}
+
+func goreGenExecUnicodeWords(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((1*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 4:
+			goto L4
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// assert 2
+	{
+		if !(goreGenUnicodeWordBoundary(s, pos)) {
+			goto fail
+		}
+	}
+L2:
+	// class [{48 57} {65 90} {95 95} {97 122} {170 170} {178 179} {181 181} {185 186} {188 190} {192 214} {216 246} {248 705} {710 721} {736 740} {748 748} {750 750} {768 884} {886 887} {890 893} {895 895} {902 902} {904 906} {908 908} {910 929} {931 1013} {1015 1153} {1155 1159} {1162 1327} {1329 1366} {1369 1369} {1376 1416} {1425 1469} {1471 1471} {1473 1474} {1476 1477} {1479 1479} {1488 1514} {1519 1522} {1552 1562} {1568 1641} {1646 1747} {1749 1756} {1759 1768} {1770 1788} {1791 1791} {1808 1866} {1869 1969} {1984 2037} {2042 2042} {2045 2045} {2048 2093} {2112 2139} {2144 2154} {2160 2183} {2185 2191} {2199 2273} {2275 2306} {2308 2362} {2364 2365} {2369 2376} {2381 2381} {2384 2403} {2406 2415} {2417 2433} {2437 2444} {2447 2448} {2451 2472} {2474 2480} {2482 2482} {2486 2489} {2492 2493} {2497 2500} {2509 2510} {2524 2525} {2527 2531} {2534 2545} {2548 2553} {2556 2556} {2558 2558} {2561 2562} {2565 2570} {2575 2576} {2579 2600} {2602 2608} {2610 2611} {2613 2614} {2616 2617} {2620 2620} {2625 2626} {2631 2632} {2635 2637} {2641 2641} {2649 2652} {2654 2654} {2662 2677} {2689 2690} {2693 2701} {2703 2705} {2707 2728} {2730 2736} {2738 2739} {2741 2745} {2748 2749} {2753 2757} {2759 2760} {2765 2765} {2768 2768} {2784 2787} {2790 2799} {2809 2815} {2817 2817} {2821 2828} {2831 2832} {2835 2856} {2858 2864} {2866 2867} {2869 2873} {2876 2877} {2879 2879} {2881 2884} {2893 2893} {2901 2902} {2908 2909} {2911 2915} {2918 2927} {2929 2935} {2946 2947} {2949 2954} {2958 2960} {2962 2965} {2969 2970} {2972 2972} {2974 2975} {2979 2980} {2984 2986} {2990 3001} {3008 3008} {3021 3021} {3024 3024} {3046 3058} {3072 3072} {3076 3084} {3086 3088} {3090 3112} {3114 3129} {3132 3136} {3142 3144} {3146 3149} {3157 3158} {3160 3162} {3164 3165} {3168 3171} {3174 3183} {3192 3198} {3200 3201} {3205 3212} {3214 3216} {3218 3240} {3242 3251} {3253 3257} {3260 3261} {3263 3263} {3270 3270} {3276 3277} {3292 3294} {3296 3299} {3302 3311} {3313 3314} {3328 3329} {3332 3340} {3342 3344} {3346 3389} {3393 3396} {3405 3406} {3412 3414} {3416 3427} {3430 3448} {3450 3455} {3457 3457} {3461 3478} {3482 3505} {3507 3515} {3517 3517} {3520 3526} {3530 3530} {3538 3540} {3542 3542} {3558 3567} {3585 3642} {3648 3662} {3664 3673} {3713 3714} {3716 3716} {3718 3722} {3724 3747} {3749 3749} {3751 3773} {3776 3780} {3782 3782} {3784 3790} {3792 3801} {3804 3807} {3840 3840} {3864 3865} {3872 3891} {3893 3893} {3895 3895} {3897 3897} {3904 3911} {3913 3948} {3953 3966} {3968 3972} {3974 3991} {3993 4028} {4038 4038} {4096 4138} {4141 4144} {4146 4151} {4153 4154} {4157 4169} {4176 4181} {4184 4193} {4197 4198} {4206 4226} {4229 4230} {4237 4238} {4240 4249} {4253 4253} {4256 4293} {4295 4295} {4301 4301} {4304 4346} {4348 4680} {4682 4685} {4688 4694} {4696 4696} {4698 4701} {4704 4744} {4746 4749} {4752 4784} {4786 4789} {4792 4798} {4800 4800} {4802 4805} {4808 4822} {4824 4880} {4882 4885} {4888 4954} {4957 4959} {4969 4988} {4992 5007} {5024 5109} {5112 5117} {5121 5740} {5743 5759} {5761 5786} {5792 5866} {5870 5880} {5888 5908} {5919 5939} {5952 5971} {5984 5996} {5998 6000} {6002 6003} {6016 6069} {6071 6077} {6086 6086} {6089 6099} {6103 6103} {6108 6109} {6112 6121} {6128 6137} {6155 6157} {6159 6169} {6176 6264} {6272 6314} {6320 6389} {6400 6430} {6432 6434} {6439 6440} {6450 6450} {6457 6459} {6470 6509} {6512 6516} {6528 6571} {6576 6601} {6608 6618} {6656 6680} {6683 6683} {6688 6740} {6742 6742} {6744 6750} {6752 6752} {6754 6754} {6757 6764} {6771 6780} {6783 6793} {6800 6809} {6823 6823} {6832 6845} {6847 6877} {6880 6891} {6912 6915} {6917 6964} {6966 6970} {6972 6972} {6978 6978} {6981 6988} {6992 7001} {7019 7027} {7040 7041} {7043 7072} {7074 7077} {7080 7081} {7083 7142} {7144 7145} {7149 7149} {7151 7153} {7168 7203} {7212 7219} {7222 7223} {7232 7241} {7245 7293} {7296 7306} {7312 7354} {7357 7359} {7376 7378} {7380 7392} {7394 7414} {7416 7418} {7424 7957} {7960 7965} {7968 8005} {8008 8013} {8016 8023} {8025 8025} {8027 8027} {8029 8029} {8031 8061} {8064 8116} {8118 8124} {8126 8126} {8130 8132} {8134 8140} {8144 8147} {8150 8155} {8160 8172} {8178 8180} {8182 8188} {8255 8256} {8276 8276} {8304 8305} {8308 8313} {8319 8329} {8336 8348} {8400 8412} {8417 8417} {8421 8432} {8450 8450} {8455 8455} {8458 8467} {8469 8469} {8473 8477} {8484 8484} {8486 8486} {8488 8488} {8490 8493} {8495 8505} {8508 8511} {8517 8521} {8526 8526} {8528 8585} {9312 9371} {9450 9471} {10102 10131} {11264 11492} {11499 11507} {11517 11517} {11520 11557} {11559 11559} {11565 11565} {11568 11623} {11631 11631} {11647 11670} {11680 11686} {11688 11694} {11696 11702} {11704 11710} {11712 11718} {11720 11726} {11728 11734} {11736 11742} {11744 11775} {11823 11823} {12293 12295} {12321 12333} {12337 12341} {12344 12348} {12353 12438} {12441 12442} {12445 12447} {12449 12538} {12540 12543} {12549 12591} {12593 12686} {12690 12693} {12704 12735} {12784 12799} {12832 12841} {12872 12879} {12881 12895} {12928 12937} {12977 12991} {13312 19903} {19968 42124} {42192 42237} {42240 42508} {42512 42539} {42560 42607} {42612 42621} {42623 42737} {42775 42783} {42786 42888} {42891 42972} {42993 43042} {43045 43046} {43052 43052} {43056 43061} {43072 43123} {43138 43187} {43204 43205} {43216 43225} {43232 43255} {43259 43259} {43261 43309} {43312 43345} {43360 43388} {43392 43394} {43396 43443} {43446 43449} {43452 43453} {43471 43481} {43488 43518} {43520 43566} {43569 43570} {43573 43574} {43584 43596} {43600 43609} {43616 43638} {43642 43642} {43644 43644} {43646 43714} {43739 43741} {43744 43754} {43756 43757} {43762 43764} {43766 43766} {43777 43782} {43785 43790} {43793 43798} {43808 43814} {43816 43822} {43824 43866} {43868 43881} {43888 44002} {44005 44005} {44008 44008} {44013 44013} {44016 44025} {44032 55203} {55216 55238} {55243 55291} {63744 64109} {64112 64217} {64256 64262} {64275 64279} {64285 64296} {64298 64310} {64312 64316} {64318 64318} {64320 64321} {64323 64324} {64326 64433} {64467 64829} {64848 64911} {64914 64967} {65008 65019} {65024 65039} {65056 65071} {65075 65076} {65101 65103} {65136 65140} {65142 65276} {65296 65305} {65313 65338} {65343 65343} {65345 65370} {65382 65470} {65474 65479} {65482 65487} {65490 65495} {65498 65500} {65536 65547} {65549 65574} {65576 65594} {65596 65597} {65599 65613} {65616 65629} {65664 65786} {65799 65843} {65856 65912} {65930 65931} {66045 66045} {66176 66204} {66208 66256} {66272 66299} {66304 66339} {66349 66378} {66384 66426} {66432 66461} {66464 66499} {66504 66511} {66513 66517} {66560 66717} {66720 66729} {66736 66771} {66776 66811} {66816 66855} {66864 66915} {66928 66938} {66940 66954} {66956 66962} {66964 66965} {66967 66977} {66979 66993} {66995 67001} {67003 67004} {67008 67059} {67072 67382} {67392 67413} {67424 67431} {67456 67461} {67463 67504} {67506 67514} {67584 67589} {67592 67592} {67594 67637} {67639 67640} {67644 67644} {67647 67669} {67672 67702} {67705 67742} {67751 67759} {67808 67826} {67828 67829} {67835 67867} {67872 67897} {67904 67929} {67968 68023} {68028 68047} {68050 68099} {68101 68102} {68108 68115} {68117 68119} {68121 68149} {68152 68154} {68159 68168} {68192 68222} {68224 68255} {68288 68295} {68297 68326} {68331 68335} {68352 68405} {68416 68437} {68440 68466} {68472 68497} {68521 68527} {68608 68680} {68736 68786} {68800 68850} {68858 68903} {68912 68921} {68928 68965} {68969 68973} {68975 68997} {69216 69246} {69248 69289} {69291 69292} {69296 69297} {69314 69319} {69370 69415} {69424 69460} {69488 69509} {69552 69579} {69600 69622} {69633 69633} {69635 69702} {69714 69749} {69759 69761} {69763 69807} {69811 69814} {69817 69818} {69826 69826} {69840 69864} {69872 69881} {69888 69931} {69933 69940} {69942 69951} {69956 69956} {69959 69959} {69968 70003} {70006 70006} {70016 70017} {70019 70066} {70070 70078} {70081 70084} {70089 70092} {70095 70106} {70108 70108} {70113 70132} {70144 70161} {70163 70187} {70191 70193} {70196 70196} {70198 70199} {70206 70209} {70272 70278} {70280 70280} {70282 70285} {70287 70301} {70303 70312} {70320 70367} {70371 70378} {70384 70393} {70400 70401} {70405 70412} {70415 70416} {70419 70440} {70442 70448} {70450 70451} {70453 70457} {70459 70461} {70464 70464} {70480 70480} {70493 70497} {70502 70508} {70512 70516} {70528 70537} {70539 70539} {70542 70542} {70544 70581} {70583 70583} {70587 70592} {70606 70606} {70608 70611} {70625 70626} {70656 70708} {70712 70719} {70722 70724} {70726 70730} {70736 70745} {70750 70753} {70784 70831} {70835 70840} {70842 70842} {70847 70848} {70850 70853} {70855 70855} {70864 70873} {71040 71086} {71090 71093} {71100 71101} {71103 71104} {71128 71133} {71168 71215} {71219 71226} {71229 71229} {71231 71232} {71236 71236} {71248 71257} {71296 71339} {71341 71341} {71344 71349} {71351 71352} {71360 71369} {71376 71395} {71424 71450} {71453 71453} {71455 71455} {71458 71461} {71463 71467} {71472 71483} {71488 71494} {71680 71723} {71727 71735} {71737 71738} {71840 71922} {71935 71942} {71945 71945} {71948 71955} {71957 71958} {71960 71983} {71995 71996} {71998 71999} {72001 72001} {72003 72003} {72016 72025} {72096 72103} {72106 72144} {72148 72151} {72154 72155} {72160 72161} {72163 72163} {72192 72248} {72250 72254} {72263 72263} {72272 72278} {72281 72342} {72344 72345} {72349 72349} {72368 72440} {72544 72544} {72546 72548} {72550 72550} {72640 72672} {72688 72697} {72704 72712} {72714 72750} {72752 72758} {72760 72765} {72767 72768} {72784 72812} {72818 72847} {72850 72871} {72874 72880} {72882 72883} {72885 72886} {72960 72966} {72968 72969} {72971 73014} {73018 73018} {73020 73021} {73023 73031} {73040 73049} {73056 73061} {73063 73064} {73066 73097} {73104 73105} {73109 73109} {73111 73112} {73120 73129} {73136 73179} {73184 73193} {73440 73460} {73472 73474} {73476 73488} {73490 73523} {73526 73530} {73536 73536} {73538 73538} {73552 73562} {73648 73648} {73664 73684} {73728 74649} {74752 74862} {74880 75075} {77712 77808} {77824 78895} {78912 78933} {78944 82938} {82944 83526} {90368 90409} {90413 90425} {92160 92728} {92736 92766} {92768 92777} {92784 92862} {92864 92873} {92880 92909} {92912 92916} {92928 92982} {92992 92995} {93008 93017} {93019 93025} {93027 93047} {93053 93071} {93504 93548} {93552 93561} {93760 93846} {93856 93880} {93883 93907} {93952 94026} {94031 94032} {94095 94111} {94176 94177} {94179 94180} {94194 94198} {94208 101589} {101631 101662} {101760 101874} {110576 110579} {110581 110587} {110589 110590} {110592 110882} {110898 110898} {110928 110930} {110933 110933} {110948 110951} {110960 111355} {113664 113770} {113776 113788} {113792 113800} {113808 113817} {113821 113822} {118000 118009} {118528 118573} {118576 118598} {119143 119145} {119163 119170} {119173 119179} {119210 119213} {119362 119364} {119488 119507} {119520 119539} {119648 119672} {119808 119892} {119894 119964} {119966 119967} {119970 119970} {119973 119974} {119977 119980} {119982 119993} {119995 119995} {119997 120003} {120005 120069} {120071 120074} {120077 120084} {120086 120092} {120094 120121} {120123 120126} {120128 120132} {120134 120134} {120138 120144} {120146 120485} {120488 120512} {120514 120538} {120540 120570} {120572 120596} {120598 120628} {120630 120654} {120656 120686} {120688 120712} {120714 120744} {120746 120770} {120772 120779} {120782 120831} {121344 121398} {121403 121452} {121461 121461} {121476 121476} {121499 121503} {121505 121519} {122624 122654} {122661 122666} {122880 122886} {122888 122904} {122907 122913} {122915 122916} {122918 122922} {122928 122989} {123023 123023} {123136 123180} {123184 123197} {123200 123209} {123214 123214} {123536 123566} {123584 123641} {124112 124153} {124368 124410} {124608 124638} {124640 124661} {124670 124671} {124896 124902} {124904 124907} {124909 124910} {124912 124926} {124928 125124} {125127 125142} {125184 125259} {125264 125273} {126065 126123} {126125 126127} {126129 126132} {126209 126253} {126255 126269} {126464 126467} {126469 126495} {126497 126498} {126500 126500} {126503 126503} {126505 126514} {126516 126519} {126521 126521} {126523 126523} {126530 126530} {126535 126535} {126537 126537} {126539 126539} {126541 126543} {126545 126546} {126548 126548} {126551 126551} {126553 126553} {126555 126555} {126557 126557} {126559 126559} {126561 126562} {126564 126564} {126567 126570} {126572 126578} {126580 126583} {126585 126588} {126590 126590} {126592 126601} {126603 126619} {126625 126627} {126629 126633} {126635 126651} {127232 127244} {130032 130041} {131072 173791} {173824 178205} {178208 183981} {183984 191456} {191472 192093} {194560 195101} {196608 201546} {201552 210041} {917760 917999}]
+	{
+		if !goreGenVisit(visited, pos*1+0) {
+			goto fail
+		}
+		if pos >= len(s) {
+			goto fail
+		}
+		r, w := goreGenStep(s, pos)
+		if !(goreGenExecUnicodeWordsSet2.has(r)) {
+			goto fail
+		}
+		pos += w
+	}
+	// split 2, 4
+	{
+		stack = append(stack, goreGenJob{pc: 4, pos: pos, reg: -1})
+		goto L2
+	}
+L4:
+	// assert 2
+	{
+		if !(goreGenUnicodeWordBoundary(s, pos)) {
+			goto fail
+		}
+	}
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+var (
+	goreGenExecUnicodeWordsSet2 = goreGenSet{ascii: [2]uint64{0x3ff000000000000, 0x7fffffe87fffffe}, ranges: []rune{0xaa, 0xaa, 0xb2, 0xb3, 0xb5, 0xb5, 0xb9, 0xba, 0xbc, 0xbe, 0xc0, 0xd6, 0xd8, 0xf6, 0xf8, 0x2c1, 0x2c6, 0x2d1, 0x2e0, 0x2e4, 0x2ec, 0x2ec, 0x2ee, 0x2ee, 0x300, 0x374, 0x376, 0x377, 0x37a, 0x37d, 0x37f, 0x37f, 0x386, 0x386, 0x388, 0x38a, 0x38c, 0x38c, 0x38e, 0x3a1, 0x3a3, 0x3f5, 0x3f7, 0x481, 0x483, 0x487, 0x48a, 0x52f, 0x531, 0x556, 0x559, 0x559, 0x560, 0x588, 0x591, 0x5bd, 0x5bf, 0x5bf, 0x5c1, 0x5c2, 0x5c4, 0x5c5, 0x5c7, 0x5c7, 0x5d0, 0x5ea, 0x5ef, 0x5f2, 0x610, 0x61a, 0x620, 0x669, 0x66e, 0x6d3, 0x6d5, 0x6dc, 0x6df, 0x6e8, 0x6ea, 0x6fc, 0x6ff, 0x6ff, 0x710, 0x74a, 0x74d, 0x7b1, 0x7c0, 0x7f5, 0x7fa, 0x7fa, 0x7fd, 0x7fd, 0x800, 0x82d, 0x840, 0x85b, 0x860, 0x86a, 0x870, 0x887, 0x889, 0x88f, 0x897, 0x8e1, 0x8e3, 0x902, 0x904, 0x93a, 0x93c, 0x93d, 0x941, 0x948, 0x94d, 0x94d, 0x950, 0x963, 0x966, 0x96f, 0x971, 0x981, 0x985, 0x98c, 0x98f, 0x990, 0x993, 0x9a8, 0x9aa, 0x9b0, 0x9b2, 0x9b2, 0x9b6, 0x9b9, 0x9bc, 0x9bd, 0x9c1, 0x9c4, 0x9cd, 0x9ce, 0x9dc, 0x9dd, 0x9df, 0x9e3, 0x9e6, 0x9f1, 0x9f4, 0x9f9, 0x9fc, 0x9fc, 0x9fe, 0x9fe, 0xa01, 0xa02, 0xa05, 0xa0a, 0xa0f, 0xa10, 0xa13, 0xa28, 0xa2a, 0xa30, 0xa32, 0xa33, 0xa35, 0xa36, 0xa38, 0xa39, 0xa3c, 0xa3c, 0xa41, 0xa42, 0xa47, 0xa48, 0xa4b, 0xa4d, 0xa51, 0xa51, 0xa59, 0xa5c, 0xa5e, 0xa5e, 0xa66, 0xa75, 0xa81, 0xa82, 0xa85, 0xa8d, 0xa8f, 0xa91, 0xa93, 0xaa8, 0xaaa, 0xab0, 0xab2, 0xab3, 0xab5, 0xab9, 0xabc, 0xabd, 0xac1, 0xac5, 0xac7, 0xac8, 0xacd, 0xacd, 0xad0, 0xad0, 0xae0, 0xae3, 0xae6, 0xaef, 0xaf9, 0xaff, 0xb01, 0xb01, 0xb05, 0xb0c, 0xb0f, 0xb10, 0xb13, 0xb28, 0xb2a, 0xb30, 0xb32, 0xb33, 0xb35, 0xb39, 0xb3c, 0xb3d, 0xb3f, 0xb3f, 0xb41, 0xb44, 0xb4d, 0xb4d, 0xb55, 0xb56, 0xb5c, 0xb5d, 0xb5f, 0xb63, 0xb66, 0xb6f, 0xb71, 0xb77, 0xb82, 0xb83, 0xb85, 0xb8a, 0xb8e, 0xb90, 0xb92, 0xb95, 0xb99, 0xb9a, 0xb9c, 0xb9c, 0xb9e, 0xb9f, 0xba3, 0xba4, 0xba8, 0xbaa, 0xbae, 0xbb9, 0xbc0, 0xbc0, 0xbcd, 0xbcd, 0xbd0, 0xbd0, 0xbe6, 0xbf2, 0xc00, 0xc00, 0xc04, 0xc0c, 0xc0e, 0xc10, 0xc12, 0xc28, 0xc2a, 0xc39, 0xc3c, 0xc40, 0xc46, 0xc48, 0xc4a, 0xc4d, 0xc55, 0xc56, 0xc58, 0xc5a, 0xc5c, 0xc5d, 0xc60, 0xc63, 0xc66, 0xc6f, 0xc78, 0xc7e, 0xc80, 0xc81, 0xc85, 0xc8c, 0xc8e, 0xc90, 0xc92, 0xca8, 0xcaa, 0xcb3, 0xcb5, 0xcb9, 0xcbc, 0xcbd, 0xcbf, 0xcbf, 0xcc6, 0xcc6, 0xccc, 0xccd, 0xcdc, 0xcde, 0xce0, 0xce3, 0xce6, 0xcef, 0xcf1, 0xcf2, 0xd00, 0xd01, 0xd04, 0xd0c, 0xd0e, 0xd10, 0xd12, 0xd3d, 0xd41, 0xd44, 0xd4d, 0xd4e, 0xd54, 0xd56, 0xd58, 0xd63, 0xd66, 0xd78, 0xd7a, 0xd7f, 0xd81, 0xd81, 0xd85, 0xd96, 0xd9a, 0xdb1, 0xdb3, 0xdbb, 0xdbd, 0xdbd, 0xdc0, 0xdc6, 0xdca, 0xdca, 0xdd2, 0xdd4, 0xdd6, 0xdd6, 0xde6, 0xdef, 0xe01, 0xe3a, 0xe40, 0xe4e, 0xe50, 0xe59, 0xe81, 0xe82, 0xe84, 0xe84, 0xe86, 0xe8a, 0xe8c, 0xea3, 0xea5, 0xea5, 0xea7, 0xebd, 0xec0, 0xec4, 0xec6, 0xec6, 0xec8, 0xece, 0xed0, 0xed9, 0xedc, 0xedf, 0xf00, 0xf00, 0xf18, 0xf19, 0xf20, 0xf33, 0xf35, 0xf35, 0xf37, 0xf37, 0xf39, 0xf39, 0xf40, 0xf47, 0xf49, 0xf6c, 0xf71, 0xf7e, 0xf80, 0xf84, 0xf86, 0xf97, 0xf99, 0xfbc, 0xfc6, 0xfc6, 0x1000, 0x102a, 0x102d, 0x1030, 0x1032, 0x1037, 0x1039, 0x103a, 0x103d, 0x1049, 0x1050, 0x1055, 0x1058, 0x1061, 0x1065, 0x1066, 0x106e, 0x1082, 0x1085, 0x1086, 0x108d, 0x108e, 0x1090, 0x1099, 0x109d, 0x109d, 0x10a0, 0x10c5, 0x10c7, 0x10c7, 0x10cd, 0x10cd, 0x10d0, 0x10fa, 0x10fc, 0x1248, 0x124a, 0x124d, 0x1250, 0x1256, 0x1258, 0x1258, 0x125a, 0x125d, 0x1260, 0x1288, 0x128a, 0x128d, 0x1290, 0x12b0, 0x12b2, 0x12b5, 0x12b8, 0x12be, 0x12c0, 0x12c0, 0x12c2, 0x12c5, 0x12c8, 0x12d6, 0x12d8, 0x1310, 0x1312, 0x1315, 0x1318, 0x135a, 0x135d, 0x135f, 0x1369, 0x137c, 0x1380, 0x138f, 0x13a0, 0x13f5, 0x13f8, 0x13fd, 0x1401, 0x166c, 0x166f, 0x167f, 0x1681, 0x169a, 0x16a0, 0x16ea, 0x16ee, 0x16f8, 0x1700, 0x1714, 0x171f, 0x1733, 0x1740, 0x1753, 0x1760, 0x176c, 0x176e, 0x1770, 0x1772, 0x1773, 0x1780, 0x17b5, 0x17b7, 0x17bd, 0x17c6, 0x17c6, 0x17c9, 0x17d3, 0x17d7, 0x17d7, 0x17dc, 0x17dd, 0x17e0, 0x17e9, 0x17f0, 0x17f9, 0x180b, 0x180d, 0x180f, 0x1819, 0x1820, 0x1878, 0x1880, 0x18aa, 0x18b0, 0x18f5, 0x1900, 0x191e, 0x1920, 0x1922, 0x1927, 0x1928, 0x1932, 0x1932, 0x1939, 0x193b, 0x1946, 0x196d, 0x1970, 0x1974, 0x1980, 0x19ab, 0x19b0, 0x19c9, 0x19d0, 0x19da, 0x1a00, 0x1a18, 0x1a1b, 0x1a1b, 0x1a20, 0x1a54, 0x1a56, 0x1a56, 0x1a58, 0x1a5e, 0x1a60, 0x1a60, 0x1a62, 0x1a62, 0x1a65, 0x1a6c, 0x1a73, 0x1a7c, 0x1a7f, 0x1a89, 0x1a90, 0x1a99, 0x1aa7, 0x1aa7, 0x1ab0, 0x1abd, 0x1abf, 0x1add, 0x1ae0, 0x1aeb, 0x1b00, 0x1b03, 0x1b05, 0x1b34, 0x1b36, 0x1b3a, 0x1b3c, 0x1b3c, 0x1b42, 0x1b42, 0x1b45, 0x1b4c, 0x1b50, 0x1b59, 0x1b6b, 0x1b73, 0x1b80, 0x1b81, 0x1b83, 0x1ba0, 0x1ba2, 0x1ba5, 0x1ba8, 0x1ba9, 0x1bab, 0x1be6, 0x1be8, 0x1be9, 0x1bed, 0x1bed, 0x1bef, 0x1bf1, 0x1c00, 0x1c23, 0x1c2c, 0x1c33, 0x1c36, 0x1c37, 0x1c40, 0x1c49, 0x1c4d, 0x1c7d, 0x1c80, 0x1c8a, 0x1c90, 0x1cba, 0x1cbd, 0x1cbf, 0x1cd0, 0x1cd2, 0x1cd4, 0x1ce0, 0x1ce2, 0x1cf6, 0x1cf8, 0x1cfa, 0x1d00, 0x1f15, 0x1f18, 0x1f1d, 0x1f20, 0x1f45, 0x1f48, 0x1f4d, 0x1f50, 0x1f57, 0x1f59, 0x1f59, 0x1f5b, 0x1f5b, 0x1f5d, 0x1f5d, 0x1f5f, 0x1f7d, 0x1f80, 0x1fb4, 0x1fb6, 0x1fbc, 0x1fbe, 0x1fbe, 0x1fc2, 0x1fc4, 0x1fc6, 0x1fcc, 0x1fd0, 0x1fd3, 0x1fd6, 0x1fdb, 0x1fe0, 0x1fec, 0x1ff2, 0x1ff4, 0x1ff6, 0x1ffc, 0x203f, 0x2040, 0x2054, 0x2054, 0x2070, 0x2071, 0x2074, 0x2079, 0x207f, 0x2089, 0x2090, 0x209c, 0x20d0, 0x20dc, 0x20e1, 0x20e1, 0x20e5, 0x20f0, 0x2102, 0x2102, 0x2107, 0x2107, 0x210a, 0x2113, 0x2115, 0x2115, 0x2119, 0x211d, 0x2124, 0x2124, 0x2126, 0x2126, 0x2128, 0x2128, 0x212a, 0x212d, 0x212f, 0x2139, 0x213c, 0x213f, 0x2145, 0x2149, 0x214e, 0x214e, 0x2150, 0x2189, 0x2460, 0x249b, 0x24ea, 0x24ff, 0x2776, 0x2793, 0x2c00, 0x2ce4, 0x2ceb, 0x2cf3, 0x2cfd, 0x2cfd, 0x2d00, 0x2d25, 0x2d27, 0x2d27, 0x2d2d, 0x2d2d, 0x2d30, 0x2d67, 0x2d6f, 0x2d6f, 0x2d7f, 0x2d96, 0x2da0, 0x2da6, 0x2da8, 0x2dae, 0x2db0, 0x2db6, 0x2db8, 0x2dbe, 0x2dc0, 0x2dc6, 0x2dc8, 0x2dce, 0x2dd0, 0x2dd6, 0x2dd8, 0x2dde, 0x2de0, 0x2dff, 0x2e2f, 0x2e2f, 0x3005, 0x3007, 0x3021, 0x302d, 0x3031, 0x3035, 0x3038, 0x303c, 0x3041, 0x3096, 0x3099, 0x309a, 0x309d, 0x309f, 0x30a1, 0x30fa, 0x30fc, 0x30ff, 0x3105, 0x312f, 0x3131, 0x318e, 0x3192, 0x3195, 0x31a0, 0x31bf, 0x31f0, 0x31ff, 0x3220, 0x3229, 0x3248, 0x324f, 0x3251, 0x325f, 0x3280, 0x3289, 0x32b1, 0x32bf, 0x3400, 0x4dbf, 0x4e00, 0xa48c, 0xa4d0, 0xa4fd, 0xa500, 0xa60c, 0xa610, 0xa62b, 0xa640, 0xa66f, 0xa674, 0xa67d, 0xa67f, 0xa6f1, 0xa717, 0xa71f, 0xa722, 0xa788, 0xa78b, 0xa7dc, 0xa7f1, 0xa822, 0xa825, 0xa826, 0xa82c, 0xa82c, 0xa830, 0xa835, 0xa840, 0xa873, 0xa882, 0xa8b3, 0xa8c4, 0xa8c5, 0xa8d0, 0xa8d9, 0xa8e0, 0xa8f7, 0xa8fb, 0xa8fb, 0xa8fd, 0xa92d, 0xa930, 0xa951, 0xa960, 0xa97c, 0xa980, 0xa982, 0xa984, 0xa9b3, 0xa9b6, 0xa9b9, 0xa9bc, 0xa9bd, 0xa9cf, 0xa9d9, 0xa9e0, 0xa9fe, 0xaa00, 0xaa2e, 0xaa31, 0xaa32, 0xaa35, 0xaa36, 0xaa40, 0xaa4c, 0xaa50, 0xaa59, 0xaa60, 0xaa76, 0xaa7a, 0xaa7a, 0xaa7c, 0xaa7c, 0xaa7e, 0xaac2, 0xaadb, 0xaadd, 0xaae0, 0xaaea, 0xaaec, 0xaaed, 0xaaf2, 0xaaf4, 0xaaf6, 0xaaf6, 0xab01, 0xab06, 0xab09, 0xab0e, 0xab11, 0xab16, 0xab20, 0xab26, 0xab28, 0xab2e, 0xab30, 0xab5a, 0xab5c, 0xab69, 0xab70, 0xabe2, 0xabe5, 0xabe5, 0xabe8, 0xabe8, 0xabed, 0xabed, 0xabf0, 0xabf9, 0xac00, 0xd7a3, 0xd7b0, 0xd7c6, 0xd7cb, 0xd7fb, 0xf900, 0xfa6d, 0xfa70, 0xfad9, 0xfb00, 0xfb06, 0xfb13, 0xfb17, 0xfb1d, 0xfb28, 0xfb2a, 0xfb36, 0xfb38, 0xfb3c, 0xfb3e, 0xfb3e, 0xfb40, 0xfb41, 0xfb43, 0xfb44, 0xfb46, 0xfbb1, 0xfbd3, 0xfd3d, 0xfd50, 0xfd8f, 0xfd92, 0xfdc7, 0xfdf0, 0xfdfb, 0xfe00, 0xfe0f, 0xfe20, 0xfe2f, 0xfe33, 0xfe34, 0xfe4d, 0xfe4f, 0xfe70, 0xfe74, 0xfe76, 0xfefc, 0xff10, 0xff19, 0xff21, 0xff3a, 0xff3f, 0xff3f, 0xff41, 0xff5a, 0xff66, 0xffbe, 0xffc2, 0xffc7, 0xffca, 0xffcf, 0xffd2, 0xffd7, 0xffda, 0xffdc, 0x10000, 0x1000b, 0x1000d, 0x10026, 0x10028, 0x1003a, 0x1003c, 0x1003d, 0x1003f, 0x1004d, 0x10050, 0x1005d, 0x10080, 0x100fa, 0x10107, 0x10133, 0x10140, 0x10178, 0x1018a, 0x1018b, 0x101fd, 0x101fd, 0x10280, 0x1029c, 0x102a0, 0x102d0, 0x102e0, 0x102fb, 0x10300, 0x10323, 0x1032d, 0x1034a, 0x10350, 0x1037a, 0x10380, 0x1039d, 0x103a0, 0x103c3, 0x103c8, 0x103cf, 0x103d1, 0x103d5, 0x10400, 0x1049d, 0x104a0, 0x104a9, 0x104b0, 0x104d3, 0x104d8, 0x104fb, 0x10500, 0x10527, 0x10530, 0x10563, 0x10570, 0x1057a, 0x1057c, 0x1058a, 0x1058c, 0x10592, 0x10594, 0x10595, 0x10597, 0x105a1, 0x105a3, 0x105b1, 0x105b3, 0x105b9, 0x105bb, 0x105bc, 0x105c0, 0x105f3, 0x10600, 0x10736, 0x10740, 0x10755, 0x10760, 0x10767, 0x10780, 0x10785, 0x10787, 0x107b0, 0x107b2, 0x107ba, 0x10800, 0x10805, 0x10808, 0x10808, 0x1080a, 0x10835, 0x10837, 0x10838, 0x1083c, 0x1083c, 0x1083f, 0x10855, 0x10858, 0x10876, 0x10879, 0x1089e, 0x108a7, 0x108af, 0x108e0, 0x108f2, 0x108f4, 0x108f5, 0x108fb, 0x1091b, 0x10920, 0x10939, 0x10940, 0x10959, 0x10980, 0x109b7, 0x109bc, 0x109cf, 0x109d2, 0x10a03, 0x10a05, 0x10a06, 0x10a0c, 0x10a13, 0x10a15, 0x10a17, 0x10a19, 0x10a35, 0x10a38, 0x10a3a, 0x10a3f, 0x10a48, 0x10a60, 0x10a7e, 0x10a80, 0x10a9f, 0x10ac0, 0x10ac7, 0x10ac9, 0x10ae6, 0x10aeb, 0x10aef, 0x10b00, 0x10b35, 0x10b40, 0x10b55, 0x10b58, 0x10b72, 0x10b78, 0x10b91, 0x10ba9, 0x10baf, 0x10c00, 0x10c48, 0x10c80, 0x10cb2, 0x10cc0, 0x10cf2, 0x10cfa, 0x10d27, 0x10d30, 0x10d39, 0x10d40, 0x10d65, 0x10d69, 0x10d6d, 0x10d6f, 0x10d85, 0x10e60, 0x10e7e, 0x10e80, 0x10ea9, 0x10eab, 0x10eac, 0x10eb0, 0x10eb1, 0x10ec2, 0x10ec7, 0x10efa, 0x10f27, 0x10f30, 0x10f54, 0x10f70, 0x10f85, 0x10fb0, 0x10fcb, 0x10fe0, 0x10ff6, 0x11001, 0x11001, 0x11003, 0x11046, 0x11052, 0x11075, 0x1107f, 0x11081, 0x11083, 0x110af, 0x110b3, 0x110b6, 0x110b9, 0x110ba, 0x110c2, 0x110c2, 0x110d0, 0x110e8, 0x110f0, 0x110f9, 0x11100, 0x1112b, 0x1112d, 0x11134, 0x11136, 0x1113f, 0x11144, 0x11144, 0x11147, 0x11147, 0x11150, 0x11173, 0x11176, 0x11176, 0x11180, 0x11181, 0x11183, 0x111b2, 0x111b6, 0x111be, 0x111c1, 0x111c4, 0x111c9, 0x111cc, 0x111cf, 0x111da, 0x111dc, 0x111dc, 0x111e1, 0x111f4, 0x11200, 0x11211, 0x11213, 0x1122b, 0x1122f, 0x11231, 0x11234, 0x11234, 0x11236, 0x11237, 0x1123e, 0x11241, 0x11280, 0x11286, 0x11288, 0x11288, 0x1128a, 0x1128d, 0x1128f, 0x1129d, 0x1129f, 0x112a8, 0x112b0, 0x112df, 0x112e3, 0x112ea, 0x112f0, 0x112f9, 0x11300, 0x11301, 0x11305, 0x1130c, 0x1130f, 0x11310, 0x11313, 0x11328, 0x1132a, 0x11330, 0x11332, 0x11333, 0x11335, 0x11339, 0x1133b, 0x1133d, 0x11340, 0x11340, 0x11350, 0x11350, 0x1135d, 0x11361, 0x11366, 0x1136c, 0x11370, 0x11374, 0x11380, 0x11389, 0x1138b, 0x1138b, 0x1138e, 0x1138e, 0x11390, 0x113b5, 0x113b7, 0x113b7, 0x113bb, 0x113c0, 0x113ce, 0x113ce, 0x113d0, 0x113d3, 0x113e1, 0x113e2, 0x11400, 0x11434, 0x11438, 0x1143f, 0x11442, 0x11444, 0x11446, 0x1144a, 0x11450, 0x11459, 0x1145e, 0x11461, 0x11480, 0x114af, 0x114b3, 0x114b8, 0x114ba, 0x114ba, 0x114bf, 0x114c0, 0x114c2, 0x114c5, 0x114c7, 0x114c7, 0x114d0, 0x114d9, 0x11580, 0x115ae, 0x115b2, 0x115b5, 0x115bc, 0x115bd, 0x115bf, 0x115c0, 0x115d8, 0x115dd, 0x11600, 0x1162f, 0x11633, 0x1163a, 0x1163d, 0x1163d, 0x1163f, 0x11640, 0x11644, 0x11644, 0x11650, 0x11659, 0x11680, 0x116ab, 0x116ad, 0x116ad, 0x116b0, 0x116b5, 0x116b7, 0x116b8, 0x116c0, 0x116c9, 0x116d0, 0x116e3, 0x11700, 0x1171a, 0x1171d, 0x1171d, 0x1171f, 0x1171f, 0x11722, 0x11725, 0x11727, 0x1172b, 0x11730, 0x1173b, 0x11740, 0x11746, 0x11800, 0x1182b, 0x1182f, 0x11837, 0x11839, 0x1183a, 0x118a0, 0x118f2, 0x118ff, 0x11906, 0x11909, 0x11909, 0x1190c, 0x11913, 0x11915, 0x11916, 0x11918, 0x1192f, 0x1193b, 0x1193c, 0x1193e, 0x1193f, 0x11941, 0x11941, 0x11943, 0x11943, 0x11950, 0x11959, 0x119a0, 0x119a7, 0x119aa, 0x119d0, 0x119d4, 0x119d7, 0x119da, 0x119db, 0x119e0, 0x119e1, 0x119e3, 0x119e3, 0x11a00, 0x11a38, 0x11a3a, 0x11a3e, 0x11a47, 0x11a47, 0x11a50, 0x11a56, 0x11a59, 0x11a96, 0x11a98, 0x11a99, 0x11a9d, 0x11a9d, 0x11ab0, 0x11af8, 0x11b60, 0x11b60, 0x11b62, 0x11b64, 0x11b66, 0x11b66, 0x11bc0, 0x11be0, 0x11bf0, 0x11bf9, 0x11c00, 0x11c08, 0x11c0a, 0x11c2e, 0x11c30, 0x11c36, 0x11c38, 0x11c3d, 0x11c3f, 0x11c40, 0x11c50, 0x11c6c, 0x11c72, 0x11c8f, 0x11c92, 0x11ca7, 0x11caa, 0x11cb0, 0x11cb2, 0x11cb3, 0x11cb5, 0x11cb6, 0x11d00, 0x11d06, 0x11d08, 0x11d09, 0x11d0b, 0x11d36, 0x11d3a, 0x11d3a, 0x11d3c, 0x11d3d, 0x11d3f, 0x11d47, 0x11d50, 0x11d59, 0x11d60, 0x11d65, 0x11d67, 0x11d68, 0x11d6a, 0x11d89, 0x11d90, 0x11d91, 0x11d95, 0x11d95, 0x11d97, 0x11d98, 0x11da0, 0x11da9, 0x11db0, 0x11ddb, 0x11de0, 0x11de9, 0x11ee0, 0x11ef4, 0x11f00, 0x11f02, 0x11f04, 0x11f10, 0x11f12, 0x11f33, 0x11f36, 0x11f3a, 0x11f40, 0x11f40, 0x11f42, 0x11f42, 0x11f50, 0x11f5a, 0x11fb0, 0x11fb0, 0x11fc0, 0x11fd4, 0x12000, 0x12399, 0x12400, 0x1246e, 0x12480, 0x12543, 0x12f90, 0x12ff0, 0x13000, 0x1342f, 0x13440, 0x13455, 0x13460, 0x143fa, 0x14400, 0x14646, 0x16100, 0x16129, 0x1612d, 0x16139, 0x16800, 0x16a38, 0x16a40, 0x16a5e, 0x16a60, 0x16a69, 0x16a70, 0x16abe, 0x16ac0, 0x16ac9, 0x16ad0, 0x16aed, 0x16af0, 0x16af4, 0x16b00, 0x16b36, 0x16b40, 0x16b43, 0x16b50, 0x16b59, 0x16b5b, 0x16b61, 0x16b63, 0x16b77, 0x16b7d, 0x16b8f, 0x16d40, 0x16d6c, 0x16d70, 0x16d79, 0x16e40, 0x16e96, 0x16ea0, 0x16eb8, 0x16ebb, 0x16ed3, 0x16f00, 0x16f4a, 0x16f4f, 0x16f50, 0x16f8f, 0x16f9f, 0x16fe0, 0x16fe1, 0x16fe3, 0x16fe4, 0x16ff2, 0x16ff6, 0x17000, 0x18cd5, 0x18cff, 0x18d1e, 0x18d80, 0x18df2, 0x1aff0, 0x1aff3, 0x1aff5, 0x1affb, 0x1affd, 0x1affe, 0x1b000, 0x1b122, 0x1b132, 0x1b132, 0x1b150, 0x1b152, 0x1b155, 0x1b155, 0x1b164, 0x1b167, 0x1b170, 0x1b2fb, 0x1bc00, 0x1bc6a, 0x1bc70, 0x1bc7c, 0x1bc80, 0x1bc88, 0x1bc90, 0x1bc99, 0x1bc9d, 0x1bc9e, 0x1ccf0, 0x1ccf9, 0x1cf00, 0x1cf2d, 0x1cf30, 0x1cf46, 0x1d167, 0x1d169, 0x1d17b, 0x1d182, 0x1d185, 0x1d18b, 0x1d1aa, 0x1d1ad, 0x1d242, 0x1d244, 0x1d2c0, 0x1d2d3, 0x1d2e0, 0x1d2f3, 0x1d360, 0x1d378, 0x1d400, 0x1d454, 0x1d456, 0x1d49c, 0x1d49e, 0x1d49f, 0x1d4a2, 0x1d4a2, 0x1d4a5, 0x1d4a6, 0x1d4a9, 0x1d4ac, 0x1d4ae, 0x1d4b9, 0x1d4bb, 0x1d4bb, 0x1d4bd, 0x1d4c3, 0x1d4c5, 0x1d505, 0x1d507, 0x1d50a, 0x1d50d, 0x1d514, 0x1d516, 0x1d51c, 0x1d51e, 0x1d539, 0x1d53b, 0x1d53e, 0x1d540, 0x1d544, 0x1d546, 0x1d546, 0x1d54a, 0x1d550, 0x1d552, 0x1d6a5, 0x1d6a8, 0x1d6c0, 0x1d6c2, 0x1d6da, 0x1d6dc, 0x1d6fa, 0x1d6fc, 0x1d714, 0x1d716, 0x1d734, 0x1d736, 0x1d74e, 0x1d750, 0x1d76e, 0x1d770, 0x1d788, 0x1d78a, 0x1d7a8, 0x1d7aa, 0x1d7c2, 0x1d7c4, 0x1d7cb, 0x1d7ce, 0x1d7ff, 0x1da00, 0x1da36, 0x1da3b, 0x1da6c, 0x1da75, 0x1da75, 0x1da84, 0x1da84, 0x1da9b, 0x1da9f, 0x1daa1, 0x1daaf, 0x1df00, 0x1df1e, 0x1df25, 0x1df2a, 0x1e000, 0x1e006, 0x1e008, 0x1e018, 0x1e01b, 0x1e021, 0x1e023, 0x1e024, 0x1e026, 0x1e02a, 0x1e030, 0x1e06d, 0x1e08f, 0x1e08f, 0x1e100, 0x1e12c, 0x1e130, 0x1e13d, 0x1e140, 0x1e149, 0x1e14e, 0x1e14e, 0x1e290, 0x1e2ae, 0x1e2c0, 0x1e2f9, 0x1e4d0, 0x1e4f9, 0x1e5d0, 0x1e5fa, 0x1e6c0, 0x1e6de, 0x1e6e0, 0x1e6f5, 0x1e6fe, 0x1e6ff, 0x1e7e0, 0x1e7e6, 0x1e7e8, 0x1e7eb, 0x1e7ed, 0x1e7ee, 0x1e7f0, 0x1e7fe, 0x1e800, 0x1e8c4, 0x1e8c7, 0x1e8d6, 0x1e900, 0x1e94b, 0x1e950, 0x1e959, 0x1ec71, 0x1ecab, 0x1ecad, 0x1ecaf, 0x1ecb1, 0x1ecb4, 0x1ed01, 0x1ed2d, 0x1ed2f, 0x1ed3d, 0x1ee00, 0x1ee03, 0x1ee05, 0x1ee1f, 0x1ee21, 0x1ee22, 0x1ee24, 0x1ee24, 0x1ee27, 0x1ee27, 0x1ee29, 0x1ee32, 0x1ee34, 0x1ee37, 0x1ee39, 0x1ee39, 0x1ee3b, 0x1ee3b, 0x1ee42, 0x1ee42, 0x1ee47, 0x1ee47, 0x1ee49, 0x1ee49, 0x1ee4b, 0x1ee4b, 0x1ee4d, 0x1ee4f, 0x1ee51, 0x1ee52, 0x1ee54, 0x1ee54, 0x1ee57, 0x1ee57, 0x1ee59, 0x1ee59, 0x1ee5b, 0x1ee5b, 0x1ee5d, 0x1ee5d, 0x1ee5f, 0x1ee5f, 0x1ee61, 0x1ee62, 0x1ee64, 0x1ee64, 0x1ee67, 0x1ee6a, 0x1ee6c, 0x1ee72, 0x1ee74, 0x1ee77, 0x1ee79, 0x1ee7c, 0x1ee7e, 0x1ee7e, 0x1ee80, 0x1ee89, 0x1ee8b, 0x1ee9b, 0x1eea1, 0x1eea3, 0x1eea5, 0x1eea9, 0x1eeab, 0x1eebb, 0x1f100, 0x1f10c, 0x1fbf0, 0x1fbf9, 0x20000, 0x2a6df, 0x2a700, 0x2b81d, 0x2b820, 0x2cead, 0x2ceb0, 0x2ebe0, 0x2ebf0, 0x2ee5d, 0x2f800, 0x2fa1d, 0x30000, 0x3134a, 0x31350, 0x33479, 0xe0100, 0xe01ef}}
+)
+
+// LinesMatchString reports whether s contains a match of
+//
+//	(?m)^(\w+):\s*(.*)$
+func LinesMatchString(s string) bool {
+	var caps [6]int
+	return goreGenExecLines(s, caps[:])
+}
+
+// LinesFindString returns the text of the leftmost match in s of
+//
+//	(?m)^(\w+):\s*(.*)$
+//
+// or "" if there is none.
+func LinesFindString(s string) string {
+	var caps [6]int
+	if !goreGenExecLines(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// LinesFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	(?m)^(\w+):\s*(.*)$
+//
+// or nil if there is none.
+func LinesFindStringIndex(s string) []int {
+	var caps [6]int
+	if !goreGenExecLines(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// LinesFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	(?m)^(\w+):\s*(.*)$
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func LinesFindStringSubmatch(s string) []string {
+	var caps [6]int
+	if !goreGenExecLines(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:6])
+}
+
+func goreGenExecLines(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((3*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 5:
+			goto L5
+		case 10:
+			goto L10
+		case 14:
+			goto L14
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// assert 0
+	{
+		if !(pos == 0 || s[pos-1] == '\n') {
+			goto fail
+		}
+	}
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+L3:
+	// class [{48 57} {65 90} {95 95} {97 122}]
+	{
+		if !goreGenVisit(visited, pos*3+0) {
+			goto fail
+		}
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c == '_' || c >= 'a' && c <= 'z') {
+			goto fail
+		}
+		pos++
+	}
+	// split 3, 5
+	{
+		stack = append(stack, goreGenJob{pc: 5, pos: pos, reg: -1})
+		goto L3
+	}
+L5:
+	// save 3
+	{
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+	// char ':'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != ':' {
+			goto fail
+		}
+		pos++
+	}
+L7:
+	// split 8, 10
+	{
+		if !goreGenVisit(visited, pos*3+1) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pc: 10, pos: pos, reg: -1})
+	}
+	// class [{9 9} {10 10} {13 13} {32 32}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '\t' && c <= '\n' || c == '\r' || c == ' ') {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 7
+	{
+		goto L7
+	}
+L10:
+	// save 4
+	{
+		stack = append(stack, goreGenJob{pos: caps[4], reg: 4})
+		caps[4] = pos
+	}
+L11:
+	// split 12, 14
+	{
+		if !goreGenVisit(visited, pos*3+2) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pc: 14, pos: pos, reg: -1})
+	}
+	// class ^[{10 10}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		r, w := goreGenStep(s, pos)
+		if !(r <= '\t' || r >= '\v') {
+			goto fail
+		}
+		pos += w
+	}
+	// jmp 11
+	{
+		goto L11
+	}
+L14:
+	// save 5
+	{
+		stack = append(stack, goreGenJob{pos: caps[5], reg: 5})
+		caps[5] = pos
+	}
+	// assert 1
+	{
+		if !(pos >= len(s) || s[pos] == 0 || s[pos] == '\n') {
+			goto fail
+		}
+	}
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// TailMatchString reports whether s contains a match of
+//
+//	\w+\Z
+func TailMatchString(s string) bool {
+	var caps [2]int
+	return goreGenExecTail(s, caps[:])
+}
+
+// TailFindString returns the text of the leftmost match in s of
+//
+//	\w+\Z
+//
+// or "" if there is none.
+func TailFindString(s string) string {
+	var caps [2]int
+	if !goreGenExecTail(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// TailFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	\w+\Z
+//
+// or nil if there is none.
+func TailFindStringIndex(s string) []int {
+	var caps [2]int
+	if !goreGenExecTail(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// TailFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	\w+\Z
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func TailFindStringSubmatch(s string) []string {
+	var caps [2]int
+	if !goreGenExecTail(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:2])
+}
+
+func goreGenExecTail(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((1*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 3:
+			goto L3
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+L1:
+	// class [{48 57} {65 90} {95 95} {97 122}]
+	{
+		if !goreGenVisit(visited, pos*1+0) {
+			goto fail
+		}
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c == '_' || c >= 'a' && c <= 'z') {
+			goto fail
+		}
+		pos++
+	}
+	// split 1, 3
+	{
+		stack = append(stack, goreGenJob{pc: 3, pos: pos, reg: -1})
+		goto L1
+	}
+L3:
+	// assert 5
+	{
+		if !(pos >= len(s) || s[pos] == 0 || s[pos] == '\n' && (pos+1 >= len(s) || s[pos+1] == 0)) {
+			goto fail
+		}
+	}
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// EndMatchString reports whether s contains a match of
+//
+//	x*\z
+func EndMatchString(s string) bool {
+	var caps [2]int
+	return goreGenExecEnd(s, caps[:])
+}
+
+// EndFindString returns the text of the leftmost match in s of
+//
+//	x*\z
+//
+// or "" if there is none.
+func EndFindString(s string) string {
+	var caps [2]int
+	if !goreGenExecEnd(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// EndFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	x*\z
+//
+// or nil if there is none.
+func EndFindStringIndex(s string) []int {
+	var caps [2]int
+	if !goreGenExecEnd(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// EndFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	x*\z
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func EndFindStringSubmatch(s string) []string {
+	var caps [2]int
+	if !goreGenExecEnd(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:2])
+}
+
+func goreGenExecEnd(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((1*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 4:
+			goto L4
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+L1:
+	// split 2, 4
+	{
+		if !goreGenVisit(visited, pos*1+0) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pc: 4, pos: pos, reg: -1})
+	}
+	// char 'x'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'x' {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 1
+	{
+		goto L1
+	}
+L4:
+	// assert 6
+	{
+		if !(pos >= len(s) || s[pos] == 0) {
+			goto fail
+		}
+	}
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// NestedMatchString reports whether s contains a match of
+//
+//	((a|ab)*)c
+func NestedMatchString(s string) bool {
+	var caps [6]int
+	return goreGenExecNested(s, caps[:])
+}
+
+// NestedFindString returns the text of the leftmost match in s of
+//
+//	((a|ab)*)c
+//
+// or "" if there is none.
+func NestedFindString(s string) string {
+	var caps [6]int
+	if !goreGenExecNested(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// NestedFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	((a|ab)*)c
+//
+// or nil if there is none.
+func NestedFindStringIndex(s string) []int {
+	var caps [6]int
+	if !goreGenExecNested(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// NestedFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	((a|ab)*)c
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func NestedFindStringSubmatch(s string) []string {
+	var caps [6]int
+	if !goreGenExecNested(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:6])
+}
+
+func goreGenExecNested(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((2*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 7:
+			goto L7
+		case 11:
+			goto L11
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+L2:
+	// split 3, 11
+	{
+		if !goreGenVisit(visited, pos*2+0) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pc: 11, pos: pos, reg: -1})
+	}
+	// save 4
+	{
+		stack = append(stack, goreGenJob{pos: caps[4], reg: 4})
+		caps[4] = pos
+	}
+	// split 5, 7
+	{
+		stack = append(stack, goreGenJob{pc: 7, pos: pos, reg: -1})
+	}
+	// char 'a'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'a' {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 9
+	{
+		goto L9
+	}
+L7:
+	// char 'a'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'a' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'b'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'b' {
+			goto fail
+		}
+		pos++
+	}
+L9:
+	// save 5
+	{
+		if !goreGenVisit(visited, pos*2+1) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[5], reg: 5})
+		caps[5] = pos
+	}
+	// jmp 2
+	{
+		goto L2
+	}
+L11:
+	// save 3
+	{
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+	// char 'c'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'c' {
+			goto fail
+		}
+		pos++
+	}
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// LazyMatchString reports whether s contains a match of
+//
+//	<(.+?)>
+func LazyMatchString(s string) bool {
+	var caps [4]int
+	return goreGenExecLazy(s, caps[:])
+}
+
+// LazyFindString returns the text of the leftmost match in s of
+//
+//	<(.+?)>
+//
+// or "" if there is none.
+func LazyFindString(s string) string {
+	var caps [4]int
+	if !goreGenExecLazy(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// LazyFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	<(.+?)>
+//
+// or nil if there is none.
+func LazyFindStringIndex(s string) []int {
+	var caps [4]int
+	if !goreGenExecLazy(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// LazyFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	<(.+?)>
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func LazyFindStringSubmatch(s string) []string {
+	var caps [4]int
+	if !goreGenExecLazy(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:4])
+}
+
+func goreGenExecLazy(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((1*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	if start < len(s) {
+		i := strings.Index(s[start:], "<")
+		if i < 0 {
+			return false
+		}
+		start += i
+	}
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 3:
+			goto L3
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// char '<'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '<' {
+			goto fail
+		}
+		pos++
+	}
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+L3:
+	// class ^[{10 10}]
+	{
+		if !goreGenVisit(visited, pos*1+0) {
+			goto fail
+		}
+		if pos >= len(s) {
+			goto fail
+		}
+		r, w := goreGenStep(s, pos)
+		if !(r <= '\t' || r >= '\v') {
+			goto fail
+		}
+		pos += w
+	}
+	// split 5, 3
+	{
+		stack = append(stack, goreGenJob{pc: 3, pos: pos, reg: -1})
+	}
+	// save 3
+	{
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+	// char '>'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '>' {
+			goto fail
+		}
+		pos++
+	}
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// OptionalMatchString reports whether s contains a match of
+//
+//	(a)?(b)?(c)?
+func OptionalMatchString(s string) bool {
+	var caps [8]int
+	return goreGenExecOptional(s, caps[:])
+}
+
+// OptionalFindString returns the text of the leftmost match in s of
+//
+//	(a)?(b)?(c)?
+//
+// or "" if there is none.
+func OptionalFindString(s string) string {
+	var caps [8]int
+	if !goreGenExecOptional(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// OptionalFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	(a)?(b)?(c)?
+//
+// or nil if there is none.
+func OptionalFindStringIndex(s string) []int {
+	var caps [8]int
+	if !goreGenExecOptional(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// OptionalFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	(a)?(b)?(c)?
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func OptionalFindStringSubmatch(s string) []string {
+	var caps [8]int
+	if !goreGenExecOptional(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:8])
+}
+
+func goreGenExecOptional(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((3*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 5:
+			goto L5
+		case 9:
+			goto L9
+		case 13:
+			goto L13
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// split 2, 5
+	{
+		stack = append(stack, goreGenJob{pc: 5, pos: pos, reg: -1})
+	}
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+	// char 'a'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'a' {
+			goto fail
+		}
+		pos++
+	}
+	// save 3
+	{
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+L5:
+	// split 6, 9
+	{
+		if !goreGenVisit(visited, pos*3+0) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pc: 9, pos: pos, reg: -1})
+	}
+	// save 4
+	{
+		stack = append(stack, goreGenJob{pos: caps[4], reg: 4})
+		caps[4] = pos
+	}
+	// char 'b'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'b' {
+			goto fail
+		}
+		pos++
+	}
+	// save 5
+	{
+		stack = append(stack, goreGenJob{pos: caps[5], reg: 5})
+		caps[5] = pos
+	}
+L9:
+	// split 10, 13
+	{
+		if !goreGenVisit(visited, pos*3+1) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pc: 13, pos: pos, reg: -1})
+	}
+	// save 6
+	{
+		stack = append(stack, goreGenJob{pos: caps[6], reg: 6})
+		caps[6] = pos
+	}
+	// char 'c'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'c' {
+			goto fail
+		}
+		pos++
+	}
+	// save 7
+	{
+		stack = append(stack, goreGenJob{pos: caps[7], reg: 7})
+		caps[7] = pos
+	}
+L13:
+	// save 1
+	{
+		if !goreGenVisit(visited, pos*3+2) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// GreekMatchString reports whether s contains a match of
+//
+//	[^ -~]+|[αβγ]
+func GreekMatchString(s string) bool {
+	var caps [2]int
+	return goreGenExecGreek(s, caps[:])
+}
+
+// GreekFindString returns the text of the leftmost match in s of
+//
+//	[^ -~]+|[αβγ]
+//
+// or "" if there is none.
+func GreekFindString(s string) string {
+	var caps [2]int
+	if !goreGenExecGreek(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// GreekFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	[^ -~]+|[αβγ]
+//
+// or nil if there is none.
+func GreekFindStringIndex(s string) []int {
+	var caps [2]int
+	if !goreGenExecGreek(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// GreekFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	[^ -~]+|[αβγ]
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func GreekFindStringSubmatch(s string) []string {
+	var caps [2]int
+	if !goreGenExecGreek(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:2])
+}
+
+func goreGenExecGreek(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((2*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 4:
+			goto L4
+		case 5:
+			goto L5
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// split 2, 5
+	{
+		stack = append(stack, goreGenJob{pc: 5, pos: pos, reg: -1})
+	}
+L2:
+	// class ^[{32 126}]
+	{
+		if !goreGenVisit(visited, pos*2+0) {
+			goto fail
+		}
+		if pos >= len(s) {
+			goto fail
+		}
+		r, w := goreGenStep(s, pos)
+		if !(r <= '\x1f' || r >= '\x7f') {
+			goto fail
+		}
+		pos += w
+	}
+	// split 2, 4
+	{
+		stack = append(stack, goreGenJob{pc: 4, pos: pos, reg: -1})
+		goto L2
+	}
+L4:
+	// jmp 6
+	{
+		goto L6
+	}
+L5:
+	// class [{945 947}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		r, w := goreGenStep(s, pos)
+		if !(r >= '\u03b1' && r <= '\u03b3') {
+			goto fail
+		}
+		pos += w
+	}
+L6:
+	// save 1
+	{
+		if !goreGenVisit(visited, pos*2+1) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// PrefixMatchString reports whether s contains a match of
+//
+//	foo(bar|baz)+
+func PrefixMatchString(s string) bool {
+	var caps [4]int
+	return goreGenExecPrefix(s, caps[:])
+}
+
+// PrefixFindString returns the text of the leftmost match in s of
+//
+//	foo(bar|baz)+
+//
+// or "" if there is none.
+func PrefixFindString(s string) string {
+	var caps [4]int
+	if !goreGenExecPrefix(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// PrefixFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	foo(bar|baz)+
+//
+// or nil if there is none.
+func PrefixFindStringIndex(s string) []int {
+	var caps [4]int
+	if !goreGenExecPrefix(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// PrefixFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	foo(bar|baz)+
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func PrefixFindStringSubmatch(s string) []string {
+	var caps [4]int
+	if !goreGenExecPrefix(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:4])
+}
+
+func goreGenExecPrefix(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((2*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	if start < len(s) {
+		i := strings.Index(s[start:], "f")
+		if i < 0 {
+			return false
+		}
+		start += i
+	}
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 10:
+			goto L10
+		case 15:
+			goto L15
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// char 'f'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'f' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'o'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'o' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'o'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'o' {
+			goto fail
+		}
+		pos++
+	}
+L4:
+	// save 2
+	{
+		if !goreGenVisit(visited, pos*2+0) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+	// split 6, 10
+	{
+		stack = append(stack, goreGenJob{pc: 10, pos: pos, reg: -1})
+	}
+	// char 'b'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'b' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'a'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'a' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'r'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'r' {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 13
+	{
+		goto L13
+	}
+L10:
+	// char 'b'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'b' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'a'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'a' {
+			goto fail
+		}
+		pos++
+	}
+	// char 'z'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'z' {
+			goto fail
+		}
+		pos++
+	}
+L13:
+	// save 3
+	{
+		if !goreGenVisit(visited, pos*2+1) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+	// split 4, 15
+	{
+		stack = append(stack, goreGenJob{pc: 15, pos: pos, reg: -1})
+		goto L4
+	}
+L15:
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// EmptyMatchString reports whether s contains a match of
+//
+//	(a|b?)+c|(x*)*$
+func EmptyMatchString(s string) bool {
+	var caps [6]int
+	return goreGenExecEmpty(s, caps[:])
+}
+
+// EmptyFindString returns the text of the leftmost match in s of
+//
+//	(a|b?)+c|(x*)*$
+//
+// or "" if there is none.
+func EmptyFindString(s string) string {
+	var caps [6]int
+	if !goreGenExecEmpty(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// EmptyFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	(a|b?)+c|(x*)*$
+//
+// or nil if there is none.
+func EmptyFindStringIndex(s string) []int {
+	var caps [6]int
+	if !goreGenExecEmpty(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// EmptyFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	(a|b?)+c|(x*)*$
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func EmptyFindStringSubmatch(s string) []string {
+	var caps [6]int
+	if !goreGenExecEmpty(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:6])
+}
+
+func goreGenExecEmpty(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	visited := make([]uint64, min((8*(len(s)+1)+63)/64, goreGenVisitBudget/8))
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if job.reg >= 0 {
+			caps[job.reg] = job.pos
+			goto fail
+		}
+		pos = job.pos
+		switch job.pc {
+		case 6:
+			goto L6
+		case 8:
+			goto L8
+		case 23:
+			goto L23
+		case 26:
+			goto L26
+		case 27:
+			goto L27
+		case 29:
+			goto L29
+		case 34:
+			goto L34
+		case 41:
+			goto L41
+		case 42:
+			goto L42
+		}
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// split 2, 29
+	{
+		stack = append(stack, goreGenJob{pc: 29, pos: pos, reg: -1})
+	}
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+	// split 4, 6
+	{
+		stack = append(stack, goreGenJob{pc: 6, pos: pos, reg: -1})
+	}
+	// char 'a'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'a' {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 8
+	{
+		goto L8
+	}
+L6:
+	// split 7, 8
+	{
+		stack = append(stack, goreGenJob{pc: 8, pos: pos, reg: -1})
+	}
+	// char 'b'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'b' {
+			goto fail
+		}
+		pos++
+	}
+L8:
+	// save 3
+	{
+		if !goreGenVisit(visited, pos*8+0) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+L9:
+	// split 18, 27
+	{
+		if !goreGenVisit(visited, pos*8+1) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pc: 27, pos: pos, reg: -1})
+		goto L18
+	}
+L13:
+	// jmp 16
+	{
+		goto L16
+	}
+L16:
+	// save 3
+	{
+		if !goreGenVisit(visited, pos*8+2) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+	// jmp 9
+	{
+		goto L9
+	}
+L18:
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+	// split 20, 23
+	{
+		stack = append(stack, goreGenJob{pc: 23, pos: pos, reg: -1})
+	}
+	// char 'a'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'a' {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 13
+	{
+		goto L13
+	}
+L23:
+	// split 24, 26
+	{
+		stack = append(stack, goreGenJob{pc: 26, pos: pos, reg: -1})
+	}
+	// char 'b'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'b' {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 16
+	{
+		goto L16
+	}
+L26:
+	// save 3
+	{
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+L27:
+	// char 'c'
+	{
+		if !goreGenVisit(visited, pos*8+3) {
+			goto fail
+		}
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'c' {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 43
+	{
+		goto L43
+	}
+L29:
+	// split 36, 42
+	{
+		if !goreGenVisit(visited, pos*8+4) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pc: 42, pos: pos, reg: -1})
+		goto L36
+	}
+L31:
+	// split 32, 34
+	{
+		stack = append(stack, goreGenJob{pc: 34, pos: pos, reg: -1})
+	}
+	// char 'x'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'x' {
+			goto fail
+		}
+		pos++
+	}
+L33:
+	// jmp 31
+	{
+		if !goreGenVisit(visited, pos*8+5) {
+			goto fail
+		}
+		goto L31
+	}
+L34:
+	// save 5
+	{
+		stack = append(stack, goreGenJob{pos: caps[5], reg: 5})
+		caps[5] = pos
+	}
+	// jmp 29
+	{
+		goto L29
+	}
+L36:
+	// save 4
+	{
+		stack = append(stack, goreGenJob{pos: caps[4], reg: 4})
+		caps[4] = pos
+	}
+	// split 38, 41
+	{
+		stack = append(stack, goreGenJob{pc: 41, pos: pos, reg: -1})
+	}
+	// char 'x'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != 'x' {
+			goto fail
+		}
+		pos++
+	}
+	// jmp 33
+	{
+		goto L33
+	}
+L41:
+	// save 5
+	{
+		stack = append(stack, goreGenJob{pos: caps[5], reg: 5})
+		caps[5] = pos
+	}
+L42:
+	// assert 1
+	{
+		if !goreGenVisit(visited, pos*8+6) {
+			goto fail
+		}
+		if !(pos >= len(s) || s[pos] == 0) {
+			goto fail
+		}
+	}
+L43:
+	// save 1
+	{
+		if !goreGenVisit(visited, pos*8+7) {
+			goto fail
+		}
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// UUIDMatchString reports whether s contains a match of
+//
+//	([0-9a-f]{8})-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-([0-9a-f]{12})
+func UUIDMatchString(s string) bool {
+	var caps [6]int
+	return goreGenExecUUID(s, caps[:])
+}
+
+// UUIDFindString returns the text of the leftmost match in s of
+//
+//	([0-9a-f]{8})-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-([0-9a-f]{12})
+//
+// or "" if there is none.
+func UUIDFindString(s string) string {
+	var caps [6]int
+	if !goreGenExecUUID(s, caps[:]) {
+		return ""
+	}
+	return s[caps[0]:caps[1]]
+}
+
+// UUIDFindStringIndex returns the start and end of the leftmost match in s
+// of
+//
+//	([0-9a-f]{8})-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-([0-9a-f]{12})
+//
+// or nil if there is none.
+func UUIDFindStringIndex(s string) []int {
+	var caps [6]int
+	if !goreGenExecUUID(s, caps[:]) {
+		return nil
+	}
+	return []int{caps[0], caps[1]}
+}
+
+// UUIDFindStringSubmatch returns the text of the leftmost match in s of
+//
+//	([0-9a-f]{8})-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-([0-9a-f]{12})
+//
+// and of its groups, with "" for groups that did not take part, or nil
+// if there is no match.
+func UUIDFindStringSubmatch(s string) []string {
+	var caps [6]int
+	if !goreGenExecUUID(s, caps[:]) {
+		return nil
+	}
+	return goreGenSubmatches(s, caps[:6])
+}
+
+func goreGenExecUUID(s string, caps []int) bool {
+	for i := range caps {
+		caps[i] = -1
+	}
+	stack := make([]goreGenJob, 0, 16)
+	start, pos := 0, 0
+next:
+	pos = start
+	goto L0
+fail:
+	if len(stack) == 0 {
+		if start == len(s) {
+			return false
+		}
+		_, w := goreGenStep(s, start)
+		start += w
+		goto next
+	}
+	{
+		job := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		caps[job.reg] = job.pos
+		goto fail
+	}
+L0:
+	// save 0
+	{
+		stack = append(stack, goreGenJob{pos: caps[0], reg: 0})
+		caps[0] = pos
+	}
+	// save 2
+	{
+		stack = append(stack, goreGenJob{pos: caps[2], reg: 2})
+		caps[2] = pos
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// save 3
+	{
+		stack = append(stack, goreGenJob{pos: caps[3], reg: 3})
+		caps[3] = pos
+	}
+	// char '-'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '-' {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// char '-'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '-' {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// char '-'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '-' {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// char '-'
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; c != '-' {
+			goto fail
+		}
+		pos++
+	}
+	// save 4
+	{
+		stack = append(stack, goreGenJob{pos: caps[4], reg: 4})
+		caps[4] = pos
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// class [{48 57} {97 102}]
+	{
+		if pos >= len(s) {
+			goto fail
+		}
+		if c := s[pos]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			goto fail
+		}
+		pos++
+	}
+	// save 5
+	{
+		stack = append(stack, goreGenJob{pos: caps[5], reg: 5})
+		caps[5] = pos
+	}
+	// save 1
+	{
+		stack = append(stack, goreGenJob{pos: caps[1], reg: 1})
+		caps[1] = pos
+	}
+	// match
+	{
+		return true
+	}
+}
+
+// goreGenVisitBudget is the memory the visited set of one call may take,
+// as for gore's DefaultMemoBudget. States at positions past what it covers
+// are not remembered.
+const goreGenVisitBudget = 32 << 20
+
+// goreGenJob is an entry of a matcher's backtracking stack: a state to
+// try, or, if reg is not negative, a register to restore to pos.
+type goreGenJob struct {
+	pc, pos, reg int
+}
+
+// goreGenStep decodes the rune at pos, which must be inside s.
+func goreGenStep(s string, pos int) (rune, int) {
+	if c := s[pos]; c < utf8.RuneSelf {
+		return rune(c), 1
+	}
+	return utf8.DecodeRuneInString(s[pos:])
+}
+
+// goreGenVisit records state i in visited, and reports whether it is the
+// first time, or past what visited covers.
+func goreGenVisit(visited []uint64, i int) bool {
+	word, bit := i/64, uint64(1)<<(i%64)
+	if word >= len(visited) {
+		return true
+	}
+	if visited[word]&bit != 0 {
+		return false
+	}
+	visited[word] |= bit
+	return true
+}
+
+// goreGenSubmatches returns the text of each group in caps.
+func goreGenSubmatches(s string, caps []int) []string {
+	result := make([]string, len(caps)/2)
+	for i := range result {
+		if start, end := caps[2*i], caps[2*i+1]; start >= 0 && end >= start {
+			result[i] = s[start:end]
+		}
+	}
+	return result
+}
+
+// goreGenSet is a large character class: a bitmap of its ASCII runes, and
+// the rest as sorted pairs of bounds.
+type goreGenSet struct {
+	ascii  [2]uint64
+	ranges []rune
+}
+
+func (set *goreGenSet) has(r rune) bool {
+	if r < utf8.RuneSelf {
+		return set.ascii[r/64]>>(r%64)&1 != 0
+	}
+	lo, hi := 0, len(set.ranges)/2
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		switch {
+		case r < set.ranges[2*m]:
+			hi = m
+		case r > set.ranges[2*m+1]:
+			lo = m + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// goreGenWordBoundary reports whether pos is between a word character and
+// something else. Word characters are ASCII, so bytes will do.
+func goreGenWordBoundary(s string, pos int) bool {
+	before := pos > 0 && goreGenIsWord(rune(s[pos-1]))
+	after := pos < len(s) && goreGenIsWord(rune(s[pos]))
+	return before != after
+}
+
+// goreGenUnicodeWordBoundary is goreGenWordBoundary for Unicode word
+// characters.
+func goreGenUnicodeWordBoundary(s string, pos int) bool {
+	before, after := false, false
+	if pos > 0 {
+		r, _ := utf8.DecodeLastRuneInString(s[:pos])
+		before = goreGenIsUnicodeWord(r)
+	}
+	if pos < len(s) {
+		r, _ := utf8.DecodeRuneInString(s[pos:])
+		after = goreGenIsUnicodeWord(r)
+	}
+	return before != after
+}
+
+func goreGenIsUnicodeWord(r rune) bool {
+	if r < utf8.RuneSelf {
+		return goreGenIsWord(r)
+	}
+	return unicode.In(r, unicode.L, unicode.N, unicode.Mn, unicode.Pc)
+}
+
+func goreGenIsWord(r rune) bool {
+	return r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_'
+}
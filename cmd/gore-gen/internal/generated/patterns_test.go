@@ -0,0 +1,119 @@
+package generated
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jackofallops/gore"
+)
+
+// matcher is the generated code for one pattern
+type matcher struct {
+	pattern      string
+	matchString  func(string) bool
+	findString   func(string) string
+	findIndex    func(string) []int
+	findSubmatch func(string) []string
+}
+
+var matchers = []matcher{
+	{`^(\d{4})-(\d{2})-(\d{2})$`, DateMatchString, DateFindString, DateFindStringIndex, DateFindStringSubmatch},
+	{`(?i)([\w.+-]+)@([\w-]+)\.(com|org)`, EmailMatchString, EmailFindString, EmailFindStringIndex, EmailFindStringSubmatch},
+	{`(GET|POST|PUT) (/\S*)(?: HTTP/(\d\.\d))?`, RequestMatchString, RequestFindString, RequestFindStringIndex, RequestFindStringSubmatch},
+	{`(?i)\b(k\w*)\b`, WordsMatchString, WordsFindString, WordsFindStringIndex, WordsFindStringSubmatch},
+	{`(*UCP)\b\w+\b`, UnicodeWordsMatchString, UnicodeWordsFindString, UnicodeWordsFindStringIndex, UnicodeWordsFindStringSubmatch},
+	{`(?m)^(\w+):\s*(.*)$`, LinesMatchString, LinesFindString, LinesFindStringIndex, LinesFindStringSubmatch},
+	{`\w+\Z`, TailMatchString, TailFindString, TailFindStringIndex, TailFindStringSubmatch},
+	{`x*\z`, EndMatchString, EndFindString, EndFindStringIndex, EndFindStringSubmatch},
+	{`((a|ab)*)c`, NestedMatchString, NestedFindString, NestedFindStringIndex, NestedFindStringSubmatch},
+	{`<(.+?)>`, LazyMatchString, LazyFindString, LazyFindStringIndex, LazyFindStringSubmatch},
+	{`(a)?(b)?(c)?`, OptionalMatchString, OptionalFindString, OptionalFindStringIndex, OptionalFindStringSubmatch},
+	{`[^ -~]+|[αβγ]`, GreekMatchString, GreekFindString, GreekFindStringIndex, GreekFindStringSubmatch},
+	{`foo(bar|baz)+`, PrefixMatchString, PrefixFindString, PrefixFindStringIndex, PrefixFindStringSubmatch},
+	{`(a|b?)+c|(x*)*$`, EmptyMatchString, EmptyFindString, EmptyFindStringIndex, EmptyFindStringSubmatch},
+	{`([0-9a-f]{8})-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-([0-9a-f]{12})`, UUIDMatchString, UUIDFindString, UUIDFindStringIndex, UUIDFindStringSubmatch},
+}
+
+var inputs = []string{
+	"",
+	"2024-01-31",
+	"2024-01-31\n",
+	"x2024-01-31",
+	"mail Bob.Smith+x@Example.COM or ann@site.org",
+	"GET /index.html HTTP/1.1",
+	"POST / PUT /a",
+	"kelvin Kilo Kelvin kk_k k-k",
+	"naïve café über 東京 x",
+	"key: value\nother:  thing\n\nlast:",
+	"word\n",
+	"word\n\n",
+	"a\x00b",
+	"xx\x00",
+	"xxx",
+	"ababac abc aac",
+	"<a> <bb> <>",
+	"abc bc c",
+	"αβγ δ ascii é",
+	"foobarbazbar foo foobaz",
+	"id=123e4567-e89b-12d3-a456-426614174000, not 123e4567-e89b-12d3-a456",
+	"\xff\xfe bad utf8 \xc3",
+	strings.Repeat("ab", 50) + "c",
+	strings.Repeat("a", 200),
+}
+
+// TestGeneratedMatchesGore tests that the generated matchers give the same
+// results as gore, both as compiled by default and on the backtracker
+func TestGeneratedMatchesGore(t *testing.T) {
+	for _, m := range matchers {
+		for _, re := range []*gore.Regexp{
+			gore.MustCompile(m.pattern),
+			gore.MustCompileWithOptions(m.pattern, gore.Options{Engine: gore.EngineBacktrack}),
+		} {
+			for _, s := range inputs {
+				if got, want := m.matchString(s), re.MatchString(s); got != want {
+					t.Errorf("%s: MatchString(%q) = %v; %v engine gives %v", m.pattern, s, got, re.Engine(), want)
+				}
+				if got, want := m.findString(s), re.FindString(s); got != want {
+					t.Errorf("%s: FindString(%q) = %q; %v engine gives %q", m.pattern, s, got, re.Engine(), want)
+				}
+				if got, want := m.findIndex(s), re.FindStringIndex(s); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s: FindStringIndex(%q) = %v; %v engine gives %v", m.pattern, s, got, re.Engine(), want)
+				}
+				if got, want := m.findSubmatch(s), re.FindStringSubmatch(s); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s: FindStringSubmatch(%q) = %q; %v engine gives %q", m.pattern, s, got, re.Engine(), want)
+				}
+			}
+		}
+	}
+}
+
+// TestGeneratedLinearTime tests that a pattern that makes a plain
+// backtracker take exponential time runs in linear time
+func TestGeneratedLinearTime(t *testing.T) {
+	s := strings.Repeat("ab", 10000)
+	if NestedMatchString(s) {
+		t.Errorf("NestedMatchString matched %d bytes without a c", len(s))
+	}
+
+	// The visited set is capped, and states past it are not remembered
+	visited := make([]uint64, 1)
+	if !goreGenVisit(visited, 64) || !goreGenVisit(visited, 64) {
+		t.Error("goreGenVisit remembered a state past the end of the set")
+	}
+}
+
+func BenchmarkGenerated(b *testing.B) {
+	s := strings.Repeat("padding words ", 20) + "mail Bob.Smith+x@Example.COM"
+	b.Run("Generated", func(b *testing.B) {
+		for b.Loop() {
+			EmailFindStringSubmatch(s)
+		}
+	})
+	b.Run("Gore", func(b *testing.B) {
+		re := gore.MustCompileWithOptions(`(?i)([\w.+-]+)@([\w-]+)\.(com|org)`, gore.Options{NoStdlib: true})
+		for b.Loop() {
+			re.FindStringSubmatch(s)
+		}
+	})
+}
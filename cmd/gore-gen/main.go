@@ -0,0 +1,129 @@
+// Command gore-gen writes Go matchers for patterns known at build time.
+//
+// It reads a Go source file for annotations of the form
+//
+//	//gore:pattern Name pattern
+//
+// where the pattern is the rest of the line, and writes a file of the same
+// package with these functions for each of them:
+//
+//	func NameMatchString(s string) bool
+//	func NameFindString(s string) string
+//	func NameFindStringIndex(s string) []int
+//	func NameFindStringSubmatch(s string) []string
+//
+// They give the same results as the methods of gore.MustCompile(pattern),
+// but run code specialized to the compiled program, with no interpreter
+// and no dependency on gore at run time. Only patterns that the Pike VM can
+// run are supported: no backreferences, lookaround or other constructs that
+// need the backtracking engine.
+//
+// The usual way to run it is from a go:generate directive in the annotated
+// file:
+//
+//	//go:generate go run github.com/jackofallops/gore/cmd/gore-gen
+//
+// which reads $GOFILE and writes its matchers to the file with _gore.go in
+// place of .go. The -o flag names another output file.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+)
+
+// annotation is the prefix of a pattern annotation.
+const annotation = "//gore:pattern "
+
+func main() {
+	out := flag.String("o", "", "output file (default: the input with _gore.go in place of .go)")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: gore-gen [-o output] [file.go]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	in := os.Getenv("GOFILE")
+	if flag.NArg() > 0 {
+		in = flag.Arg(0)
+	}
+	if in == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *out == "" {
+		*out = strings.TrimSuffix(in, ".go") + "_gore.go"
+	}
+
+	if err := run(in, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "gore-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the matchers for the annotations in the file in and writes
+// them to out.
+func run(in, out string) error {
+	pkg, patterns, err := readAnnotations(in)
+	if err != nil {
+		return err
+	}
+	src, err := generate(pkg, patterns)
+	if err != nil {
+		return fmt.Errorf("%s: %v", in, err)
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+// pattern is an annotated pattern.
+type pattern struct {
+	name, expr string
+	line       int
+}
+
+// readAnnotations returns the package name of the file and its annotated
+// patterns.
+func readAnnotations(file string) (string, []pattern, error) {
+	ast, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return "", nil, err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	var patterns []pattern
+	seen := make(map[string]int) // Line of each name
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		// Only the indentation goes, as spaces at the end belong to the pattern
+		text := strings.TrimLeft(scanner.Text(), " \t")
+		if !strings.HasPrefix(text, annotation) {
+			continue
+		}
+		name, expr, ok := strings.Cut(strings.TrimPrefix(text, annotation), " ")
+		if !ok || !token.IsIdentifier(name) || !token.IsExported(name) {
+			return "", nil, fmt.Errorf("%s:%d: want %sName pattern, with an exported Name", file, line, annotation)
+		}
+		if prev, ok := seen[name]; ok {
+			return "", nil, fmt.Errorf("%s:%d: %s already annotated at line %d", file, line, name, prev)
+		}
+		seen[name] = line
+		patterns = append(patterns, pattern{name: name, expr: expr, line: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, err
+	}
+	if len(patterns) == 0 {
+		return "", nil, fmt.Errorf("%s: no %s annotations", file, strings.TrimSpace(annotation))
+	}
+	return ast.Name.Name, patterns, nil
+}
@@ -0,0 +1,83 @@
+package gore
+
+// code is the compact form of an instruction that the backtracker runs. An
+// Inst spreads its operands over a dozen fields, most of them unused by any
+// one opcode, and copying it on every step cost more than the step itself.
+// code packs them into 32 bytes: the flags into a bit set, and classes and
+// subprograms into side tables of the Prog that val indexes.
+type code struct {
+	op    uint8 // OpCode
+	flags uint8
+	idx   int32 // Inst.Idx
+	out   int32 // Inst.Out
+	out1  int32 // Inst.Out1
+
+	// val holds the rune of OpChar, the class of OpCharClass, the
+	// assertion of OpAssert, the subprogram of OpLookaround, OpAtomic and
+	// OpAbsent, and Max for OpRepeat.
+	val int
+
+	// arg holds Inst.Arg, or Min for OpRepeat.
+	arg int
+}
+
+// Flags of a code.
+const (
+	codeFoldCase uint8 = 1 << iota
+	codeNegated
+	codeReverse
+	codeMultiline
+	codeUnicode
+	codeLookNeg
+	codeGreedy
+)
+
+// compact fills in prog.code from prog.Insts, and does the same for every
+// subprogram.
+func (prog *Prog) compact() {
+	prog.code = make([]code, len(prog.Insts))
+	prog.classes, prog.subs = nil, nil
+	for pc := range prog.Insts {
+		inst := &prog.Insts[pc]
+		c := code{
+			op:   uint8(inst.Op),
+			idx:  int32(inst.Idx),
+			out:  int32(inst.Out),
+			out1: int32(inst.Out1),
+			arg:  inst.Arg,
+		}
+		for _, f := range []struct {
+			set  bool
+			flag uint8
+		}{
+			{inst.FoldCase, codeFoldCase},
+			{inst.Negated, codeNegated},
+			{inst.Reverse, codeReverse},
+			{inst.Multiline, codeMultiline},
+			{inst.Unicode, codeUnicode},
+			{inst.LookNeg, codeLookNeg},
+			{inst.Greedy, codeGreedy},
+		} {
+			if f.set {
+				c.flags |= f.flag
+			}
+		}
+
+		switch inst.Op {
+		case OpChar:
+			c.val = int(inst.Val)
+		case OpCharClass:
+			c.val = len(prog.classes)
+			prog.classes = append(prog.classes, inst.Ranges)
+		case OpAssert:
+			c.val = int(inst.Assert)
+		case OpLookaround, OpAtomic, OpAbsent:
+			inst.Prog.compact()
+			c.val = len(prog.subs)
+			prog.subs = append(prog.subs, inst.Prog)
+		case OpRepeat:
+			c.val, c.arg = inst.Max, inst.Min
+		}
+		prog.code[pc] = c
+	}
+}
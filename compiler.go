@@ -1,8 +1,35 @@
 package gore
 
+import "fmt"
+
+// MaxProgSize is the most instructions a pattern may compile to, counting
+// those of its subprograms.
+const MaxProgSize = 100000
+
+// maxUnroll is the largest count of a {n,m} quantifier that is compiled as
+// copies of its body. Larger counts use a repeat counter, which keeps the
+// program proportional to the size of the body.
+const maxUnroll = 8
+
+// maxRegularUnroll is maxUnroll for patterns that the counters alone keep
+// from the Pike VM. Copying the body keeps them off the backtracker, as long
+// as the program stays within MaxProgSize.
+const maxRegularUnroll = 1000
+
 // Compiler compiles an AST into a VM Program.
 type Compiler struct {
-	insts []Inst
+	insts   []Inst
+	numCap  int           // Capture groups in the whole pattern, shared with subprograms
+	numRegs int           // Registers allocated so far (root compiler only)
+	parent  *Compiler     // Enclosing compiler for lookaround subprograms
+	reverse bool          // Emit code that matches backwards (lookbehind bodies)
+	unroll  int           // Largest count compiled as copies of the body (root compiler only)
+	noCopy  bool          // Never copy loop bodies that can match empty (root compiler only)
+	copies  []*loopCopies // Loop bodies being emitted twice, innermost last
+
+	stackBase int // First capture stack register, 0 if there are none
+
+	size int // Instructions emitted, including subprograms (root compiler only)
 }
 
 func NewCompiler() *Compiler {
@@ -10,7 +37,40 @@ func NewCompiler() *Compiler {
 }
 
 func (c *Compiler) Compile(node Node, numCaptures int) (*Prog, error) {
+	prog, err := c.compile(node, numCaptures, maxUnroll, false)
+	if err != nil {
+		// The copies of loop bodies that can match empty double with each
+		// loop nested in another
+		prog, err = c.compile(node, numCaptures, maxUnroll, true)
+	}
+	if err == nil && prog.onlyCountersIrregular() {
+		if unrolled, err := c.compile(node, numCaptures, maxRegularUnroll, false); err == nil {
+			prog = unrolled
+		}
+	}
+	return prog, err
+}
+
+// compile compiles node with counts up to unroll copied out, and with loop
+// bodies that can match empty copied unless noCopy is set.
+func (c *Compiler) compile(node Node, numCaptures, unroll int, noCopy bool) (*Prog, error) {
 	c.insts = nil // reset
+	c.unroll = unroll
+	c.noCopy = noCopy
+	c.numCap = numCaptures + 1 // +1 for implicit group 0
+	c.numRegs = c.numCap * 2
+	c.stackBase = 0
+	c.size = 0
+
+	// Balancing groups need a capture stack register for every group
+	walkNode(node, func(n Node) {
+		if cp, ok := n.(*Capture); (ok && cp.Stacked) || n.Type() == NodeBalance {
+			c.stackBase = c.numRegs
+		}
+	})
+	if c.stackBase != 0 {
+		c.numRegs += c.numCap
+	}
 
 	// Implicit Capture Group 0 (Whole Match)
 	// Save(0) -> Body -> Save(1) -> Match
@@ -21,21 +81,100 @@ func (c *Compiler) Compile(node Node, numCaptures int) (*Prog, error) {
 	start := 0 // Start is always 0 now
 
 	c.emit(Inst{Op: OpMatch})
+	if c.size > MaxProgSize {
+		return nil, &Error{
+			Code: ErrPatternTooLarge,
+			Msg:  fmt.Sprintf("pattern compiles to more than %d instructions", MaxProgSize),
+		}
+	}
 
 	prog := &Prog{
-		Insts:             c.insts,
-		Start:             start,
-		NumCap:            numCaptures + 1, // +1 for implicit group 0
-		LookbehindLengths: make(map[int]int),
+		Insts:     c.insts,
+		Start:     start,
+		NumCap:    c.numCap,
+		NumRegs:   c.numRegs,
+		StackBase: c.stackBase,
 	}
 
 	// Analyze pattern for optimizations
 	prog.Prefix = c.analyzePrefix(node)
-	c.analyzeLookbehinds(prog)
+	if prog.Prefix == "" && !c.reverse {
+		prog.prefilter = c.analyzePrefilter(node, prog)
+	}
+	prog.compact()
 
 	return prog, nil
 }
 
+// onlyCountersIrregular reports whether prog would be regular but for its
+// counted repetitions.
+func (prog *Prog) onlyCountersIrregular() bool {
+	counters := false
+	for _, inst := range prog.Insts {
+		switch inst.Op {
+		case OpMatch, OpChar, OpCharClass, OpAny, OpJmp, OpSplit, OpSave, OpAssert:
+		case OpCountReset, OpRepeat, OpCountInc:
+			counters = true
+		default:
+			return false
+		}
+	}
+	return counters
+}
+
+// compileReverse compiles node into a program that matches it backwards, for
+// finding where a match starts from where it ends.
+func (c *Compiler) compileReverse(node Node, numCaptures int) (*Prog, error) {
+	c.reverse = true
+	prog, err := c.Compile(node, numCaptures)
+	if prog != nil {
+		prog.Prefix, prog.prefilter = "", nil
+	}
+	return prog, err
+}
+
+// compileSub compiles a lookaround body into its own program. The subprogram
+// shares capture numbering with the enclosing pattern, so groups inside the
+// assertion are written to the same registers as the outer match. Reverse
+// programs start at the current position and consume input backwards, so a
+// lookbehind of any length is a single linear pass.
+func (c *Compiler) compileSub(node Node, reverse bool) *Prog {
+	subC := &Compiler{numCap: c.numCap, parent: c, reverse: reverse, stackBase: c.stackBase}
+	subC.compileNode(node)
+	subC.emit(Inst{Op: OpMatch})
+
+	return &Prog{
+		Insts:     subC.insts,
+		Start:     0,
+		NumCap:    c.numCap,
+		StackBase: c.stackBase,
+	}
+}
+
+// allocReg reserves a scratch register after the capture registers. Scratch
+// registers live in the same slice as captures, so backtracking restores them
+// along with everything else.
+func (c *Compiler) allocReg() int {
+	root := c.rootCompiler()
+	root.numRegs++
+	return root.numRegs - 1
+}
+
+// rootCompiler returns the compiler of the whole pattern.
+func (c *Compiler) rootCompiler() *Compiler {
+	root := c
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+// tooLarge reports whether the program has outgrown MaxProgSize, after
+// which there is no point emitting more copies of anything.
+func (c *Compiler) tooLarge() bool {
+	return c.rootCompiler().size > MaxProgSize
+}
+
 // analyzePrefix extracts a literal prefix from the pattern for fast searching
 func (c *Compiler) analyzePrefix(node Node) string {
 	switch n := node.(type) {
@@ -57,80 +196,30 @@ func (c *Compiler) analyzePrefix(node Node) string {
 	return ""
 }
 
-// analyzeLookbehinds finds fixed-length lookbehind patterns
-func (c *Compiler) analyzeLookbehinds(prog *Prog) {
-	for pc, inst := range prog.Insts {
-		if inst.Op == OpLookaround && inst.LookBehind {
-			// Analyze the lookbehind subprogram
-			length := c.analyzeFixedLength(inst.Prog, inst.Prog.Start, 0)
-			prog.LookbehindLengths[pc] = length
-		}
-	}
-}
-
-// analyzeFixedLength determines if a pattern has fixed length
-// Returns length if fixed, 0 if variable
-func (c *Compiler) analyzeFixedLength(prog *Prog, pc int, currentLen int) int {
-	visited := make(map[int]bool)
-	return c.analyzeFixedLengthRec(prog, pc, currentLen, visited)
-}
-
-func (c *Compiler) analyzeFixedLengthRec(prog *Prog, pc int, currentLen int, visited map[int]bool) int {
-	if visited[pc] || pc >= len(prog.Insts) {
-		return 0 // Cycle or invalid = variable length
-	}
-	visited[pc] = true
-
-	inst := prog.Insts[pc]
-	switch inst.Op {
-	case OpMatch:
-		return currentLen
-
-	case OpChar:
-		// Single rune = 1 byte typically, but could be multi-byte
-		// For simplicity, count as 1 rune
-		return c.analyzeFixedLengthRec(prog, pc+1, currentLen+1, visited)
-
-	case OpCharClass, OpAny:
-		return c.analyzeFixedLengthRec(prog, pc+1, currentLen+1, visited)
-
-	case OpJmp:
-		return c.analyzeFixedLengthRec(prog, inst.Out, currentLen, visited)
-
-	case OpSplit:
-		// Both branches must have same length
-		len1 := c.analyzeFixedLengthRec(prog, inst.Out, currentLen, visited)
-		len2 := c.analyzeFixedLengthRec(prog, inst.Out1, currentLen, visited)
-		if len1 == len2 && len1 > 0 {
-			return len1
-		}
-		return 0 // Variable length
-
-	case OpSave:
-		return c.analyzeFixedLengthRec(prog, pc+1, currentLen, visited)
-
-	case OpAssert:
-		return c.analyzeFixedLengthRec(prog, pc+1, currentLen, visited)
-
-	default:
-		return 0 // Unknown = variable
-	}
-}
-
 func (c *Compiler) emit(i Inst) int {
+	c.rootCompiler().size++
 	c.insts = append(c.insts, i)
-	return len(c.insts) - 1
+	pc := len(c.insts) - 1
+	if len(c.copies) > 0 && (i.Op == OpChar || i.Op == OpCharClass) {
+		c.readRune(pc)
+	}
+	return pc
 }
 
 func (c *Compiler) compileNode(node Node) int {
 	switch n := node.(type) {
 	case *Literal:
 		start := -1
-		for i, r := range n.Runes {
+		for i := range n.Runes {
+			r := n.Runes[i]
+			if c.reverse {
+				r = n.Runes[len(n.Runes)-1-i]
+			}
 			idx := c.emit(Inst{
 				Op:       OpChar,
 				Val:      r,
 				FoldCase: n.FoldCase,
+				Reverse:  c.reverse,
 			})
 			if i == 0 {
 				start = idx
@@ -144,15 +233,20 @@ func (c *Compiler) compileNode(node Node) int {
 			Ranges:   n.Ranges,
 			Negated:  n.Negated,
 			FoldCase: n.FoldCase,
+			Reverse:  c.reverse,
 		})
 
 	case *Concat:
 		if len(n.Nodes) == 0 {
 			return -1
 		}
-		start := c.compileNode(n.Nodes[0])
-		for i := 1; i < len(n.Nodes); i++ {
-			c.compileNode(n.Nodes[i])
+		start := len(c.insts)
+		for i := range n.Nodes {
+			if c.reverse {
+				c.compileNode(n.Nodes[len(n.Nodes)-1-i])
+			} else {
+				c.compileNode(n.Nodes[i])
+			}
 		}
 		return start
 
@@ -192,9 +286,22 @@ func (c *Compiler) compileNode(node Node) int {
 		return c.compileQuantifier(n)
 
 	case *Capture:
-		idx1 := c.emit(Inst{Op: OpSave, Idx: 2 * n.Index})
+		if n.Stacked {
+			reg := c.allocReg()
+			start := c.emit(Inst{Op: OpSave, Idx: reg})
+			c.compileNode(n.Body)
+			c.emit(Inst{Op: OpPushCap, Idx: n.Index, Arg: reg})
+			return start
+		}
+
+		// Backwards, the end of the group is reached first
+		first, last := 2*n.Index, 2*n.Index+1
+		if c.reverse {
+			first, last = last, first
+		}
+		idx1 := c.emit(Inst{Op: OpSave, Idx: first})
 		c.compileNode(n.Body)
-		c.emit(Inst{Op: OpSave, Idx: 2*n.Index + 1})
+		c.emit(Inst{Op: OpSave, Idx: last})
 		return idx1
 
 	case *Assertion:
@@ -202,48 +309,121 @@ func (c *Compiler) compileNode(node Node) int {
 			Op:        OpAssert,
 			Assert:    n.Kind,
 			Multiline: n.Multiline,
+			Unicode:   n.Unicode,
 		})
 
 	case *Lookaround:
-		subC := NewCompiler()
-		subProg, _ := subC.Compile(n.Body, 0) // Lookaround captures are independent
+		if n.NonAtomic {
+			return c.compileNonAtomicLookaround(n)
+		}
+		return c.emit(Inst{
+			Op:      OpLookaround,
+			Prog:    c.compileSub(n.Body, n.Behind),
+			LookNeg: n.Negative,
+		})
 
+	case *ScriptRun:
+		if n.Atomic {
+			return c.emit(Inst{
+				Op:   OpAtomic,
+				Prog: c.compileSub(&ScriptRun{Body: n.Body}, c.reverse),
+			})
+		}
+		reg := c.allocReg()
+		start := c.emit(Inst{Op: OpSave, Idx: reg})
+		c.compileNode(n.Body)
+		c.emit(Inst{Op: OpScriptRun, Idx: reg})
+		return start
+
+	case *Absent:
+		// The body is checked against every prefix of the text the operator
+		// consumes, so it runs in the opposite direction: backwards from the
+		// candidate end of the prefix.
 		return c.emit(Inst{
-			Op:         OpLookaround,
-			Prog:       subProg,
-			LookNeg:    n.Negative,
-			LookBehind: n.Behind,
+			Op:      OpAbsent,
+			Prog:    c.compileSub(n.Body, !c.reverse),
+			Reverse: c.reverse,
 		})
 
+	case *Balance:
+		// reg holds the start of the body; popcap leaves the popped
+		// capture in the two registers after it.
+		reg := c.allocReg()
+		c.allocReg()
+		c.allocReg()
+		start := c.emit(Inst{Op: OpSave, Idx: reg})
+		c.compileNode(n.Body)
+		c.emit(Inst{Op: OpPopCap, Idx: n.Pop, Arg: reg})
+		if n.Index != 0 {
+			c.emit(Inst{Op: OpTransferCap, Idx: n.Index, Arg: reg})
+		}
+		return start
+
+	case *Conditional:
+		cond := c.emit(Inst{Op: OpCond, Idx: n.Group})
+		c.insts[cond].Out = len(c.insts)
+		c.compileNode(n.Yes)
+		jmp := c.emit(Inst{Op: OpJmp})
+		c.insts[cond].Out1 = len(c.insts)
+		c.compileNode(n.No)
+		c.insts[jmp].Out = len(c.insts)
+		return cond
+
 	case *Backreference:
 		return c.emit(Inst{
-			Op:  OpBackref,
-			Idx: n.Index,
+			Op:      OpBackref,
+			Idx:     n.Index,
+			Reverse: c.reverse,
 		})
 	}
 	return -1
 }
 
+// compileNonAtomicLookaround emits a positive lookaround inline, so that the
+// body's choice points stay on the backtrack path of the enclosing match.
+// Lookbehind bodies are emitted in reverse.
+//
+// save r; body; restore r
+func (c *Compiler) compileNonAtomicLookaround(n *Lookaround) int {
+	reg := c.allocReg()
+	start := c.emit(Inst{Op: OpSave, Idx: reg})
+
+	reverse := c.reverse
+	c.reverse = n.Behind
+	c.compileNode(n.Body)
+	c.reverse = reverse
+
+	c.emit(Inst{Op: OpRestorePos, Idx: reg})
+	return start
+}
+
 func (c *Compiler) compileQuantifier(q *Quantifier) int {
 	start := len(c.insts)
 
 	if q.Min == 0 && q.Max == -1 { // *
 		split := c.emit(Inst{Op: OpSplit})
-		c.compileNode(q.Body)
-		c.emit(Inst{Op: OpJmp, Out: split})
+		body := c.compileLoopBody(q.Body, split)
 
 		end := len(c.insts)
 		if q.Greedy {
-			c.insts[split].Out = start + 1
+			c.insts[split].Out = body
 			c.insts[split].Out1 = end
 		} else {
 			c.insts[split].Out = end
-			c.insts[split].Out1 = start + 1
+			c.insts[split].Out1 = body
 		}
 		return split
 	}
 
 	if q.Min == 1 && q.Max == -1 { // +
+		if canMatchEmpty(q.Body) {
+			// The first iteration may match empty, so the ones after it
+			// are a * loop, which leaves after an iteration that does
+			c.compileNode(q.Body)
+			c.compileQuantifier(&Quantifier{Body: q.Body, Min: 0, Max: -1, Greedy: q.Greedy})
+			return start
+		}
+
 		bodyStart := c.compileNode(q.Body)
 		split := c.emit(Inst{Op: OpSplit})
 
@@ -273,9 +453,14 @@ func (c *Compiler) compileQuantifier(q *Quantifier) int {
 		return split
 	}
 
+	// Large counts loop on a counter instead of copying the body
+	if q.Min > c.rootCompiler().unroll || q.Max > c.rootCompiler().unroll {
+		return c.compileCountedQuantifier(q)
+	}
+
 	// {n} - exactly n times
 	if q.Min == q.Max && q.Max > 0 {
-		for i := 0; i < q.Min; i++ {
+		for i := 0; i < q.Min && !c.tooLarge(); i++ {
 			c.compileNode(q.Body)
 		}
 		return start
@@ -284,12 +469,12 @@ func (c *Compiler) compileQuantifier(q *Quantifier) int {
 	// {n,m} - between n and m times (inclusive)
 	if q.Min >= 0 && q.Max > q.Min {
 		// Required repetitions
-		for i := 0; i < q.Min; i++ {
+		for i := 0; i < q.Min && !c.tooLarge(); i++ {
 			c.compileNode(q.Body)
 		}
 
 		// Optional repetitions (max - min)
-		for i := 0; i < q.Max-q.Min; i++ {
+		for i := 0; i < q.Max-q.Min && !c.tooLarge(); i++ {
 			split := c.emit(Inst{Op: OpSplit})
 			bodyStart := len(c.insts)
 			c.compileNode(q.Body)
@@ -309,26 +494,146 @@ func (c *Compiler) compileQuantifier(q *Quantifier) int {
 	// {n,} - n or more times
 	if q.Min > 0 && q.Max == -1 {
 		// Required repetitions
-		for i := 0; i < q.Min; i++ {
+		for i := 0; i < q.Min && !c.tooLarge(); i++ {
 			c.compileNode(q.Body)
 		}
 
 		// Then * (zero or more)
-		split := c.emit(Inst{Op: OpSplit})
-		bodyStart := len(c.insts)
-		c.compileNode(q.Body)
-		c.emit(Inst{Op: OpJmp, Out: split})
-		end := len(c.insts)
+		c.compileQuantifier(&Quantifier{Body: q.Body, Min: 0, Max: -1, Greedy: q.Greedy})
+		return start
+	}
 
-		if q.Greedy {
-			c.insts[split].Out = bodyStart
-			c.insts[split].Out1 = end
-		} else {
-			c.insts[split].Out = end
-			c.insts[split].Out1 = bodyStart
+	return -1
+}
+
+// compileLoopBody emits the body of an unbounded loop that goes back to
+// loop after each iteration, and returns where an iteration starts. An
+// iteration that matches empty would go round again from the same position
+// forever, so, as in PCRE2, it leaves the loop instead.
+//
+// A body that only reads runes is emitted twice. Iterations start in the
+// second copy, and each rune read there goes on in the first, which alone
+// goes back to loop:
+//
+//	L: split E, end
+//	N: body
+//	   jmp L
+//	E: body, with each rune read followed by a jmp into N
+//	end:
+//
+// Whether an iteration has read anything is then where it is in the program,
+// not in a register, so the Pike VM, the DFA and memoization, which merge
+// threads at the same instruction and position, see what the backtracker
+// does. Other bodies save where the iteration starts and check it at the end:
+//
+//	L: split body, end
+//	body: save r
+//	...
+//	progress r, L
+//	end:
+func (c *Compiler) compileLoopBody(body Node, loop int) int {
+	start := len(c.insts)
+	switch {
+	case !canMatchEmpty(body):
+		c.compileNode(body)
+		c.emit(Inst{Op: OpJmp, Out: loop})
+		return start
+
+	case c.readsOnlyRunes(body) && !c.tooLarge():
+		copies := &loopCopies{}
+		c.copies = append(c.copies, copies)
+		c.compileNode(body)
+		c.emit(Inst{Op: OpJmp, Out: loop})
+		copies.empty = true
+		entry := len(c.insts)
+		c.compileNode(body)
+		c.copies = c.copies[:len(c.copies)-1]
+		return entry
+	}
+
+	reg := c.allocReg()
+	c.emit(Inst{Op: OpSave, Idx: reg})
+	c.compileNode(body)
+	c.emit(Inst{Op: OpProgress, Idx: reg, Out: loop})
+	return start
+}
+
+// loopCopies tracks the two copies of a loop body that compileLoopBody
+// emits.
+type loopCopies struct {
+	empty bool  // Emitting the copy for iterations that have read nothing
+	after []int // Where each rune read goes on in the other copy
+	reads int   // Runes read so far in the copy for empty iterations
+}
+
+// readRune is called after the instruction at pc, which reads a rune, is
+// emitted. A rune read in the copy of a loop body for empty iterations goes
+// on from the same rune read in the other copy. If several loops are in
+// that state, the outermost one decides, and the inner ones follow as the
+// other copy of it continues.
+func (c *Compiler) readRune(pc int) {
+	target := -1
+	for _, copies := range c.copies {
+		if !copies.empty {
+			copies.after = append(copies.after, pc+1)
+			continue
 		}
+		// The copies differ if the program outgrew MaxProgSize
+		if target < 0 && copies.reads < len(copies.after) {
+			target = copies.after[copies.reads]
+		}
+		copies.reads++
+	}
+	if target >= 0 {
+		c.emit(Inst{Op: OpJmp, Out: target})
+	}
+}
+
+// readsOnlyRunes reports whether node reads input one rune at a time and
+// keeps no state outside the capture registers, so that compileLoopBody can
+// copy it.
+func (c *Compiler) readsOnlyRunes(node Node) bool {
+	root := c.rootCompiler()
+	unroll := root.unroll
+	ok := !root.noCopy
+	walkNode(node, func(n Node) {
+		switch n := n.(type) {
+		case *Literal, *CharClass, *Concat, *Alternate, *Assertion:
+		case *Capture:
+			ok = ok && !n.Stacked
+		case *Quantifier:
+			ok = ok && n.Min <= unroll && n.Max <= unroll
+		default:
+			ok = false
+		}
+	})
+	return ok
+}
+
+// compileCountedQuantifier emits a repetition that counts its iterations in
+// a scratch register, so the body is compiled once whatever the counts.
+// Backtracking restores the register along with the captures.
+//
+//	countreset r
+//	L: repeat r {min,max} -> body, end
+//	body: ...
+//	countinc r -> L
+//	end:
+func (c *Compiler) compileCountedQuantifier(q *Quantifier) int {
+	if q.Max == -1 && canMatchEmpty(q.Body) {
+		// Count the required repetitions, then loop as * does, which stops
+		// an iteration that matches empty from going round forever
+		start := c.compileCountedQuantifier(&Quantifier{Body: q.Body, Min: q.Min, Max: q.Min, Greedy: q.Greedy})
+		c.compileQuantifier(&Quantifier{Body: q.Body, Min: 0, Max: -1, Greedy: q.Greedy})
 		return start
 	}
 
-	return -1
+	reg := c.allocReg()
+	start := c.emit(Inst{Op: OpCountReset, Idx: reg})
+	loop := c.emit(Inst{Op: OpRepeat, Idx: reg, Min: q.Min, Max: q.Max, Greedy: q.Greedy})
+	c.compileNode(q.Body)
+	c.emit(Inst{Op: OpCountInc, Idx: reg, Out: loop})
+	c.insts[loop].Out = loop + 1
+	c.insts[loop].Out1 = len(c.insts)
+	return start
 }
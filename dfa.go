@@ -0,0 +1,423 @@
+package gore
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// The lazy DFA answers match-only and index-only queries for regular
+// programs. Each DFA state is the ordered list of threads the Pike VM would
+// hold at a position, with no registers, so states are built on demand and
+// cached with the transitions between them. A forward DFA finds where the
+// leftmost-first match ends; a reverse DFA, anchored at that end, then finds
+// where it starts. Captures, when they are needed, come from running the
+// Pike VM over just that span.
+
+// dfaStateLimit is the most states one DFA caches. A full cache is flushed,
+// and a search that keeps filling it gives up and leaves the work to the
+// Pike VM.
+const dfaStateLimit = 4096
+
+// dfaFlushLimit is how often a single search may flush the cache before it
+// gives up.
+const dfaFlushLimit = 3
+
+// errDFAGaveUp reports that the DFA could not finish a search within its
+// cache.
+var errDFAGaveUp = errors.New("gore: DFA state cache exhausted")
+
+// Classes of the rune on one side of a position, as assertions see them.
+// dfaNone and dfaZero are both set at either end of the input.
+const (
+	dfaNone        uint8 = 1 << iota // No rune: the start or end of the input
+	dfaZero                          // Rune 0, which Step also returns at the end
+	dfaNewline                       // '\n'
+	dfaWord                          // An ASCII word character
+	dfaUnicodeWord                   // A Unicode word character
+	dfaMatched                       // State flag: a match has been seen, so no new threads start
+	dfaSideMask    = dfaMatched - 1
+)
+
+// dfaClass returns the class of rune r, where w is 0 if there is no rune.
+func dfaClass(r rune, w int) uint8 {
+	if w == 0 {
+		return dfaNone | dfaZero
+	}
+	var c uint8
+	if r == 0 {
+		c |= dfaZero
+	}
+	if r == '\n' {
+		c |= dfaNewline
+	}
+	if isWordChar(r) {
+		c |= dfaWord
+	}
+	if isUnicodeWordChar(r) {
+		c |= dfaUnicodeWord
+	}
+	return c
+}
+
+// dfaAssert is checkAssertion for the classes of the runes either side of
+// a position.
+func dfaAssert(inst *Inst, before, after uint8) bool {
+	switch inst.Assert {
+	case AssertStartText:
+		return before&dfaNone != 0 || inst.Multiline && before&dfaNewline != 0
+	case AssertEndText:
+		return after&dfaZero != 0 || inst.Multiline && after&dfaNewline != 0
+	case AssertWordBoundary, AssertNotWordBoundary:
+		word := dfaWord
+		if inst.Unicode {
+			word = dfaUnicodeWord
+		}
+		boundary := before&word != after&word
+		return boundary == (inst.Assert == AssertWordBoundary)
+	case AssertStringStart:
+		return before&dfaNone != 0
+	case AssertAbsoluteEnd:
+		return after&dfaZero != 0
+	}
+	return true
+}
+
+// dfaCapable reports whether a DFA can run prog. \Z looks two runes ahead,
+// which the state classes do not record.
+func dfaCapable(prog *Prog) bool {
+	for _, inst := range prog.Insts {
+		if inst.Op == OpAssert && inst.Assert == AssertStringEnd {
+			return false
+		}
+	}
+	return prog.isRegular()
+}
+
+// dfaState is a state of a lazy DFA.
+type dfaState struct {
+	insts []int // Threads before following empty transitions, in priority order
+	flags uint8 // Class of the rune already read, and dfaMatched
+
+	ascii *[128]dfaEdge    // Transitions on ASCII runes, made on first use
+	other map[rune]dfaEdge // Transitions on other runes
+	end   *dfaEdge         // Transition at the end of the input
+}
+
+// dfaEdge is a transition: the state after reading a rune, and whether the
+// program matched at the position before it.
+type dfaEdge struct {
+	next  *dfaState
+	match bool
+}
+
+// dead reports whether no thread is left, and none can start, so the
+// search is over.
+func (s *dfaState) dead(reverse bool) bool {
+	return len(s.insts) == 0 && (reverse || s.flags&dfaMatched != 0)
+}
+
+// dfa is a lazily built DFA over a regular program. A forward DFA searches
+// for the end of the leftmost-first match. A reverse DFA runs a reverse
+// program backwards from a match end, anchored there, and keeps every thread
+// so that it finds the leftmost start.
+type dfa struct {
+	prog    *Prog
+	reverse bool
+	states  map[string]*dfaState
+	flushes int // Flushes during the current search
+
+	// Scratch space for building states
+	list, next, stack []int
+	seen              []uint32
+	gen               uint32
+	key               []byte
+}
+
+func newDFA(prog *Prog, reverse bool) *dfa {
+	return &dfa{
+		prog:    prog,
+		reverse: reverse,
+		states:  make(map[string]*dfaState),
+		seen:    make([]uint32, len(prog.Insts)),
+	}
+}
+
+// state returns the cached state for a list of threads and flags, making it
+// if it is new.
+func (d *dfa) state(insts []int, flags uint8) (*dfaState, error) {
+	d.key = append(d.key[:0], flags)
+	for _, pc := range insts {
+		d.key = strconv.AppendInt(d.key, int64(pc), 36)
+		d.key = append(d.key, ',')
+	}
+	if s, ok := d.states[string(d.key)]; ok {
+		return s, nil
+	}
+	if len(d.states) >= dfaStateLimit {
+		if d.flushes++; d.flushes > dfaFlushLimit {
+			return nil, errDFAGaveUp
+		}
+		clear(d.states)
+	}
+	s := &dfaState{insts: append([]int(nil), insts...), flags: flags}
+	d.states[string(d.key)] = s
+	return s, nil
+}
+
+// edge returns the transition from s on rune r, where w is 0 at the end of
+// the input.
+func (d *dfa) edge(s *dfaState, r rune, w int) (dfaEdge, error) {
+	switch {
+	case w == 0:
+		if s.end != nil {
+			return *s.end, nil
+		}
+	case r >= 0 && r < 128:
+		if s.ascii != nil && s.ascii[r].next != nil {
+			return s.ascii[r], nil
+		}
+	default:
+		if e, ok := s.other[r]; ok {
+			return e, nil
+		}
+	}
+
+	e, err := d.build(s, r, w)
+	if err != nil {
+		return dfaEdge{}, err
+	}
+	switch {
+	case w == 0:
+		s.end = &e
+	case r >= 0 && r < 128:
+		if s.ascii == nil {
+			s.ascii = new([128]dfaEdge)
+		}
+		s.ascii[r] = e
+	default:
+		if s.other == nil {
+			s.other = make(map[rune]dfaEdge)
+		}
+		s.other[r] = e
+	}
+	return e, nil
+}
+
+// build computes the transition from s on rune r the way the Pike VM steps
+// its threads: follow empty transitions from each thread in priority order,
+// then keep the threads whose instruction accepts r.
+func (d *dfa) build(s *dfaState, r rune, w int) (dfaEdge, error) {
+	class := dfaClass(r, w)
+	before, after := s.flags&dfaSideMask, class
+	if d.reverse {
+		before, after = class, s.flags&dfaSideMask
+	}
+
+	d.gen++
+	d.list = d.list[:0]
+	for _, pc := range s.insts {
+		d.closure(pc, before, after)
+	}
+	if !d.reverse && s.flags&dfaMatched == 0 {
+		// A thread starting here has the lowest priority
+		d.closure(d.prog.Start, before, after)
+	}
+
+	match := false
+	d.next = d.next[:0]
+	for _, pc := range d.list {
+		inst := &d.prog.Insts[pc]
+		if inst.Op == OpMatch {
+			match = true
+			if !d.reverse {
+				break // Threads after this one can only lose to it
+			}
+			continue
+		}
+		if w > 0 && instAccepts(inst, r) {
+			d.next = append(d.next, pc+1)
+		}
+	}
+
+	flags := class
+	if !d.reverse && (match || s.flags&dfaMatched != 0) {
+		flags |= dfaMatched
+	}
+	next, err := d.state(d.next, flags)
+	return dfaEdge{next: next, match: match}, err
+}
+
+// closure appends to d.list the threads reachable from pc without reading
+// input, in priority order.
+func (d *dfa) closure(pc int, before, after uint8) {
+	d.stack = append(d.stack[:0], pc)
+	for len(d.stack) > 0 {
+		pc := d.stack[len(d.stack)-1]
+		d.stack = d.stack[:len(d.stack)-1]
+		if d.seen[pc] == d.gen {
+			continue
+		}
+		d.seen[pc] = d.gen
+
+		inst := &d.prog.Insts[pc]
+		switch inst.Op {
+		case OpJmp:
+			d.stack = append(d.stack, inst.Out)
+		case OpSplit:
+			d.stack = append(d.stack, inst.Out1, inst.Out)
+		case OpSave:
+			d.stack = append(d.stack, pc+1)
+		case OpAssert:
+			if dfaAssert(inst, before, after) {
+				d.stack = append(d.stack, pc+1)
+			}
+		default:
+			d.list = append(d.list, pc)
+		}
+	}
+}
+
+// instAccepts reports whether a consuming instruction accepts r.
+func instAccepts(inst *Inst, r rune) bool {
+	switch inst.Op {
+	case OpChar:
+		if inst.FoldCase {
+			return simpleFoldEqual(r, inst.Val)
+		}
+		return r == inst.Val
+	case OpCharClass:
+		return matchClass(r, inst.Ranges, inst.Negated, inst.FoldCase)
+	case OpAny:
+		return r != '\n'
+	}
+	return false
+}
+
+// searchForward returns where the leftmost-first match that starts at or
+// after pos ends, or -1 if there is none. With earliest set it stops at the
+// first position where any match ends, which is enough to tell whether
+// there is one.
+func (d *dfa) searchForward(re *Regexp, vm *VM, pos int, earliest bool) (int, error) {
+	input := vm.input
+	interruptible := vm.ctx != nil || !vm.deadline.IsZero()
+	d.flushes = 0
+
+	s, err := d.state(nil, dfaClass(input.Context(pos)))
+	if err != nil {
+		return -1, err
+	}
+	end := -1
+	for step := 0; ; step++ {
+		if interruptible && step&(interruptInterval-1) == 0 && vm.checkInterrupt() {
+			return -1, vm.err
+		}
+
+		// With no threads running, skip to where the prefix occurs next
+		if len(s.insts) == 0 && s.flags&dfaMatched == 0 && re.prog.canSkip() {
+			next := input.Index(re, pos)
+			if next == -1 {
+				return end, nil
+			}
+			if next != pos {
+				pos = next
+				if s, err = d.state(nil, dfaClass(input.Context(pos))); err != nil {
+					return -1, err
+				}
+			}
+		}
+
+		r, w := input.Step(pos)
+		e, err := d.edge(s, r, w)
+		if err != nil {
+			return -1, err
+		}
+		if e.match {
+			end = pos
+			if earliest {
+				return end, nil
+			}
+		}
+		if w == 0 || e.next.dead(false) {
+			return end, nil
+		}
+		s = e.next
+		pos += w
+	}
+}
+
+// searchReverse returns the leftmost position, no earlier than limit, from
+// which the program matches exactly up to end, or -1 if there is none.
+func (d *dfa) searchReverse(vm *VM, limit, end int) (int, error) {
+	input := vm.input
+	interruptible := vm.ctx != nil || !vm.deadline.IsZero()
+	d.flushes = 0
+
+	s, err := d.state([]int{d.prog.Start}, dfaClass(input.Step(end)))
+	if err != nil {
+		return -1, err
+	}
+	start := -1
+	for pos, step := end, 0; ; step++ {
+		if interruptible && step&(interruptInterval-1) == 0 && vm.checkInterrupt() {
+			return -1, vm.err
+		}
+		r, w := input.Context(pos)
+		e, err := d.edge(s, r, w)
+		if err != nil {
+			return -1, err
+		}
+		if e.match {
+			start = pos
+		}
+		if pos == limit || w == 0 || e.next.dead(true) {
+			return start, nil
+		}
+		s = e.next
+		pos -= w
+	}
+}
+
+// dfaPair is the forward and reverse DFA of a pattern, used together by
+// one search at a time.
+type dfaPair struct {
+	forward, reverse *dfa
+}
+
+// newDFAPool returns a pool of DFAs for a program and its reverse. Each
+// goroutine builds up a cache of its own, so searches need no locking.
+func newDFAPool(prog, reverse *Prog) *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			return &dfaPair{forward: newDFA(prog, false), reverse: newDFA(reverse, true)}
+		},
+	}
+}
+
+// findDFA returns the span of the leftmost-first match that starts at or
+// after pos, as the first two registers, or nil if there is none. It
+// returns errDFAGaveUp if the caches could not hold the search.
+func (re *Regexp) findDFA(vm *VM, pos int) ([]int, error) {
+	p := re.dfaPool.Get().(*dfaPair)
+	defer re.dfaPool.Put(p)
+
+	end, err := p.forward.searchForward(re, vm, pos, false)
+	if end < 0 || err != nil {
+		return nil, err
+	}
+	start, err := p.reverse.searchReverse(vm, pos, end)
+	if start < 0 || err != nil {
+		return nil, err
+	}
+	vm.caps = append(vm.caps[:0], start, end)
+	return vm.caps, nil
+}
+
+// matchDFA reports whether there is a match that starts at or after pos.
+func (re *Regexp) matchDFA(vm *VM, pos int) (bool, error) {
+	p := re.dfaPool.Get().(*dfaPair)
+	defer re.dfaPool.Put(p)
+
+	end, err := p.forward.searchForward(re, vm, pos, true)
+	return end >= 0, err
+}
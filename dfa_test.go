@@ -0,0 +1,121 @@
+package gore
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestDFAMatchesBacktrack tests that the DFA finds the same spans as the
+// backtracker
+func TestDFAMatchesBacktrack(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+	}{
+		{`a|ab`, "abab"},
+		{`(a|ab)(c|bcd)(d*)`, "abcd abcd"},
+		{`a*?`, "aaa"},
+		{`x*`, "axxbx"},
+		{``, "abc"},
+		{`\w+@\w+\.com`, "mail bob@example.com, al@x.com"},
+		{`^abc`, "abc abc"},
+		{`abc$`, "abc abc"},
+		{`(?m)^\w+$`, "one\ntwo\n\nthree\n"},
+		{`(?m)$`, "a\nb\n"},
+		{`\bfoo\b`, "foo food afoo foo"},
+		{`\Bo\B`, "foo boot o"},
+		{`(*UCP)\bé\w*`, "café école été"},
+		{`(?i)hello|world`, "HeLLo WORLD"},
+		{`(?s)a.c`, "a\nc abc"},
+		{`a.c`, "a\nc abc"},
+		{`\Aab|cd\z`, "abcd abcd"},
+		{`[^aeiou\s]+`, "rhythm and blues"},
+		{`é+|ü`, "café ü éé"},
+		{`(a+)+b`, "aaaaa aab"},
+		{`\d{2,4}-\d{1,2}`, "2024-1 99-12 1-1"},
+		{`needle\d*`, strings.Repeat("hay ", 50) + "needle42 needle"},
+	}
+	for _, tc := range tests {
+		re := MustCompileWithOptions(tc.pattern, Options{Engine: EnginePike})
+		if re.dfaPool == nil {
+			t.Errorf("%s: no DFA", tc.pattern)
+			continue
+		}
+		bt := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack})
+
+		if got, want := re.MatchString(tc.input), bt.MatchString(tc.input); got != want {
+			t.Errorf("MatchString(%q, %q) = %v; backtracker gives %v", tc.pattern, tc.input, got, want)
+		}
+		if got, want := re.FindStringIndex(tc.input), bt.FindStringIndex(tc.input); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindStringIndex(%q, %q) = %v; backtracker gives %v", tc.pattern, tc.input, got, want)
+		}
+		if got, want := re.FindAllStringIndex(tc.input, -1), bt.FindAllStringIndex(tc.input, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAllStringIndex(%q, %q) = %v; backtracker gives %v", tc.pattern, tc.input, got, want)
+		}
+		if got, want := re.FindAllStringSubmatch(tc.input, -1), bt.FindAllStringSubmatch(tc.input, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAllStringSubmatch(%q, %q) = %q; backtracker gives %q", tc.pattern, tc.input, got, want)
+		}
+		if got, want := re.Split(tc.input, -1), bt.Split(tc.input, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("Split(%q, %q) = %q; backtracker gives %q", tc.pattern, tc.input, got, want)
+		}
+	}
+}
+
+// TestDFACapable tests which programs get a DFA
+func TestDFACapable(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{`abc`, true},
+		{`(?m)^a$`, true},
+		{`\bword\b`, true},
+		{`abc\Z`, false},
+		{`(a)\1`, false},
+	}
+	for _, tc := range tests {
+		if got := MustCompile(tc.pattern).dfaPool != nil; got != tc.want {
+			t.Errorf("%s: has DFA = %v; want %v", tc.pattern, got, tc.want)
+		}
+	}
+}
+
+// TestDFAGivesUp tests that a pattern with more states than the cache holds
+// falls back to the Pike VM
+func TestDFAGivesUp(t *testing.T) {
+	// The DFA has to remember the last 14 runes
+	re := MustCompile(`[ab]*a` + strings.Repeat(`[ab]`, 13) + `c`)
+	rng := rand.New(rand.NewSource(1))
+	var b strings.Builder
+	for b.Len() < 200000 {
+		b.WriteByte("ab"[rng.Intn(2)])
+	}
+	input := b.String() + "a" + strings.Repeat("b", 13) + "c"
+
+	if _, err := re.findDFA(re.newVM(context.Background(), NewStringInput(input)), 0); err != errDFAGaveUp {
+		t.Fatalf("findDFA: err = %v; want errDFAGaveUp", err)
+	}
+	if got := re.FindStringIndex(input); got == nil || got[1] != len(input) {
+		t.Errorf("FindStringIndex = %v; want a match ending at %d", got, len(input))
+	}
+	if !re.MatchString(input) {
+		t.Errorf("MatchString = false; want true")
+	}
+}
+
+// TestDFAContext tests that the DFA stops when its context is done
+func TestDFAContext(t *testing.T) {
+	re := MustCompile(`(a|b)*c`)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := re.FindStringIndexContext(canceled, strings.Repeat("ab", 1000)); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v; want context.Canceled", err)
+	}
+	if _, err := re.FindAllStringIndexContext(canceled, strings.Repeat("ab", 1000), -1); !errors.Is(err, context.Canceled) {
+		t.Errorf("FindAll: err = %v; want context.Canceled", err)
+	}
+}
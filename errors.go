@@ -0,0 +1,98 @@
+package gore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorCode identifies the kind of a compile error. Codes are stable, so
+// callers can compare against them; the messages that go with them are not.
+type ErrorCode string
+
+const (
+	ErrUnexpectedChar        ErrorCode = "unexpected character"
+	ErrUnexpectedParen       ErrorCode = "unexpected )"
+	ErrMissingParen          ErrorCode = "missing closing )"
+	ErrMissingBracket        ErrorCode = "missing closing ]"
+	ErrTrailingBackslash     ErrorCode = "trailing backslash at end of expression"
+	ErrInvalidEscape         ErrorCode = "invalid escape sequence"
+	ErrInvalidCharRange      ErrorCode = "invalid character class range"
+	ErrMissingRepeatArgument ErrorCode = "missing argument to repetition operator"
+	ErrInvalidRepeat         ErrorCode = "invalid repetition"
+	ErrInvalidRepeatSize     ErrorCode = "invalid repeat count"
+	ErrInvalidFlag           ErrorCode = "invalid flag"
+	ErrInvalidGroup          ErrorCode = "invalid group syntax"
+	ErrInvalidGroupName      ErrorCode = "invalid capture group name"
+	ErrDuplicateGroupName    ErrorCode = "duplicate capture group name"
+	ErrInvalidGroupRef       ErrorCode = "invalid group reference"
+	ErrInvalidConditional    ErrorCode = "invalid conditional group"
+	ErrInvalidOptionItem     ErrorCode = "invalid option item"
+	ErrUnsupported           ErrorCode = "unsupported feature"
+	ErrPatternTooLarge       ErrorCode = "pattern too large"
+)
+
+// Error describes a problem with a pattern, found while compiling it.
+type Error struct {
+	Code    ErrorCode
+	Offset  int    // Byte offset in Pattern where the problem was found
+	Pattern string // The pattern being compiled
+	Msg     string // Description of the problem
+}
+
+func (e *Error) Error() string {
+	msg := e.Msg
+	if msg == "" {
+		msg = string(e.Code)
+	}
+	return fmt.Sprintf("%s at offset %d", msg, e.Offset)
+}
+
+// Caret renders the line of the pattern that holds the problem, with a caret
+// under the offending character:
+//
+//	(?<year>\d{4})-(?<year>\d{2})
+//	                  ^
+func (e *Error) Caret() string {
+	offset := e.Offset
+	if offset > len(e.Pattern) {
+		offset = len(e.Pattern)
+	}
+	lineStart := strings.LastIndexByte(e.Pattern[:offset], '\n') + 1
+	lineEnd := strings.IndexByte(e.Pattern[offset:], '\n')
+	if lineEnd == -1 {
+		lineEnd = len(e.Pattern)
+	} else {
+		lineEnd += offset
+	}
+
+	// Tabs keep their width so the caret lines up under them
+	var pad strings.Builder
+	for _, r := range e.Pattern[lineStart:offset] {
+		if r == '\t' {
+			pad.WriteRune('\t')
+		} else {
+			pad.WriteByte(' ')
+		}
+	}
+	return e.Pattern[lineStart:lineEnd] + "\n" + pad.String() + "^"
+}
+
+// errorf returns an *Error for the pattern being parsed.
+func (p *Parser) errorf(code ErrorCode, offset int, format string, args ...any) *Error {
+	return &Error{
+		Code:    code,
+		Offset:  offset,
+		Pattern: p.input,
+		Msg:     fmt.Sprintf(format, args...),
+	}
+}
+
+// unclosed returns the error for a group whose closing parenthesis is missing,
+// pointing at its opening parenthesis.
+func (p *Parser) unclosed(what string) *Error {
+	offset := p.pos
+	if n := len(p.groups); n > 0 {
+		offset = p.groups[n-1]
+	}
+	return p.errorf(ErrMissingParen, offset, "unclosed %s", what)
+}
@@ -1,3 +1,3 @@
-module gore
+module github.com/jackofallops/gore
 
 go 1.25.3
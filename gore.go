@@ -1,42 +1,57 @@
 package gore
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"sync"
+	"time"
 )
 
 type Regexp struct {
 	expr        string
 	prog        *Prog
 	subexpNames []string
+	opts        Options
+	engine      Engine
+	pikePool    *sync.Pool // Working memory for the Pike VM
+	dfaPool     *sync.Pool // Lazy DFAs, if the program can run on one
+	onePass     *onePass   // The program analyzed for the one-pass engine
+	std         *stdlib    // The standard library's version, if calls are handed to it
+	memoPlan    *memoPlan  // What the backtracker memoizes, if Options.Memoize is set
 }
 
-func Compile(expr string) (*Regexp, error) {
-	parser := NewParser(expr)
-	node, err := parser.Parse()
-	if err != nil {
-		return nil, err
-	}
+// Flags change how a pattern is compiled. Each flag can also be turned on
+// inside the pattern.
+type Flags uint
 
-	compiler := NewCompiler()
-	prog, err := compiler.Compile(node, parser.captures)
-	if err != nil {
-		return nil, err
-	}
+const (
+	// DupNames allows several groups to share a name, like (?J). As in
+	// PCRE2, each of them still has a number of its own; a reference to the
+	// name uses the first of them that is set.
+	DupNames Flags = 1 << iota
 
-	// Build subexp names from parser
-	names := make([]string, parser.captures+1)
-	for name, idx := range parser.names {
-		if idx < len(names) {
-			names[idx] = name
-		}
-	}
+	// NoAutoCapture makes plain (...) groups non-capturing, like (?n), so
+	// that only named groups capture.
+	NoAutoCapture
+
+	// Lenient treats an escaped letter or digit that gore does not know,
+	// such as \x or \p, as that literal character instead of an error. It
+	// also leaves [:alpha:] inside a class as plain characters.
+	Lenient
+)
 
-	return &Regexp{
-		expr:        expr,
-		prog:        prog,
-		subexpNames: names,
-	}, nil
+func Compile(expr string) (*Regexp, error) {
+	return CompileWithOptions(expr, Options{})
+}
+
+// CompileFlags is like Compile but starts the pattern with the given flags set.
+func CompileFlags(expr string, flags Flags) (*Regexp, error) {
+	return CompileWithOptions(expr, Options{
+		DupNames:      flags&DupNames != 0,
+		NoAutoCapture: flags&NoAutoCapture != 0,
+		Lenient:       flags&Lenient != 0,
+	})
 }
 
 func MustCompile(expr string) *Regexp {
@@ -47,6 +62,15 @@ func MustCompile(expr string) *Regexp {
 	return re
 }
 
+// MustCompileFlags is like CompileFlags but panics if the expression cannot be parsed.
+func MustCompileFlags(expr string, flags Flags) *Regexp {
+	re, err := CompileFlags(expr, flags)
+	if err != nil {
+		panic(fmt.Sprintf("gore: CompileFlags(%q): %v", expr, err))
+	}
+	return re
+}
+
 // NumSubexp returns the number of parenthesized subexpressions in this Regexp.
 func (re *Regexp) NumSubexp() int {
 	return len(re.subexpNames) - 1
@@ -74,6 +98,12 @@ func (re *Regexp) String() string {
 	return re.expr
 }
 
+// Prog returns the program re was compiled to, for tools such as
+// cmd/gore-gen that turn it into code. It must not be modified.
+func (re *Regexp) Prog() *Prog {
+	return re.prog
+}
+
 // LiteralPrefix returns a literal string that must begin any match
 // of the regular expression re. It returns the boolean true if the
 // literal string comprises the entire regular expression.
@@ -87,9 +117,24 @@ func (re *Regexp) LiteralPrefix() (prefix string, complete bool) {
 	return re.prog.Prefix, false
 }
 
+// MatchString reports whether s contains any match of the regular
+// expression. A search that exceeds the limits reports false; use
+// MatchStringErr to tell the two apart.
 func (re *Regexp) MatchString(s string) bool {
-	input := NewStringInput(s)
-	return re.match(input)
+	matched, _ := re.MatchStringErr(s)
+	return matched
+}
+
+// MatchStringErr is like MatchString but returns an error wrapping
+// ErrMatchLimit if the search exceeds the limits.
+func (re *Regexp) MatchStringErr(s string) (bool, error) {
+	return re.MatchStringContext(context.Background(), s)
+}
+
+// MatchStringContext is like MatchStringErr but also stops, returning
+// ctx.Err(), once ctx is done.
+func (re *Regexp) MatchStringContext(ctx context.Context, s string) (bool, error) {
+	return re.match(ctx, NewStringInput(s))
 }
 
 func (re *Regexp) MatchReader(r io.Reader) (bool, error) {
@@ -97,43 +142,115 @@ func (re *Regexp) MatchReader(r io.Reader) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return re.match(input), nil
+	return re.match(context.Background(), input)
 }
 
 func (re *Regexp) FindStringSubmatch(s string) []string {
-	input := NewStringInput(s)
+	match, _ := re.FindStringSubmatchErr(s)
+	return match
+}
+
+// FindStringSubmatchErr is like FindStringSubmatch but returns an error
+// wrapping ErrMatchLimit if the search exceeds the limits.
+func (re *Regexp) FindStringSubmatchErr(s string) ([]string, error) {
+	return re.FindStringSubmatchContext(context.Background(), s)
+}
+
+// FindStringSubmatchContext is like FindStringSubmatchErr but also stops,
+// returning ctx.Err(), once ctx is done.
+func (re *Regexp) FindStringSubmatchContext(ctx context.Context, s string) ([]string, error) {
+	caps, err := re.find(re.newVM(ctx, NewStringInput(s)), 0)
+	if caps == nil {
+		return nil, err
+	}
+	return re.submatches(s, caps), nil
+}
+
+func (re *Regexp) match(ctx context.Context, input Input) (bool, error) {
+	if s, ok := re.stdInput(input); ok {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		return re.std.re.MatchString(s), nil
+	}
+	vm := re.newVM(ctx, input)
+	if re.dfaPool != nil {
+		if matched, err := re.matchDFA(vm, 0); err != errDFAGaveUp {
+			return matched, err
+		}
+	}
+	caps, err := re.find(vm, 0)
+	return caps != nil, err
+}
+
+// newVM returns a VM that searches input within the limits of re, for a call
+// that stops when ctx is done or the match timeout passes.
+func (re *Regexp) newVM(ctx context.Context, input Input) *VM {
 	vm := NewVM(re.prog, input)
+	vm.limits = re.opts.Limits.resolve()
+	if ctx.Done() != nil {
+		vm.ctx = ctx
+	}
+	if re.opts.MatchTimeout > 0 {
+		vm.timeout = re.opts.MatchTimeout
+		vm.deadline = time.Now().Add(vm.timeout)
+	}
+	return vm
+}
+
+// find returns the registers of the leftmost match that starts at or after
+// pos, or nil if there is none. Each start position it tries has a budget of
+// its own, as in PCRE2, so that the limits do not shrink as the input grows.
+func (re *Regexp) find(vm *VM, pos int) ([]int, error) {
+	if s, ok := re.stdInput(vm.input); ok && pos == 0 {
+		return re.findStd(vm, s, true)
+	}
+	if re.engine == EngineOnePass {
+		return re.findOnePass(vm, pos)
+	}
+	if re.engine == EnginePike {
+		if re.dfaPool != nil {
+			// Let the DFA find where the match starts, so that the Pike VM
+			// only has the match itself left to run
+			span, err := re.findDFA(vm, pos)
+			switch {
+			case err == errDFAGaveUp:
+			case span == nil || err != nil:
+				return nil, err
+			default:
+				pos = span[0]
+			}
+		}
+		return re.findPike(vm, pos)
+	}
+	if re.memoPlan != nil {
+		vm.resetMemo(re.memoPlan, pos)
+	}
 
 	// Unanchored search through input (including EOF for empty matches)
+	input := vm.input
 	inputLen := input.Len()
-	pos := 0
 	for pos <= inputLen {
 		// Use prefix search to skip impossible positions
-		if re.prog.Prefix != "" && pos < inputLen {
+		if re.prog.canSkip() && pos < inputLen {
 			prefixPos := input.Index(re, pos)
 			if prefixPos == -1 {
-				return nil // No prefix found
+				return nil, nil // No prefix found anywhere
 			}
 			pos = prefixPos
 		}
 
+		if (vm.ctx != nil || !vm.deadline.IsZero()) && vm.checkInterrupt() {
+			return nil, vm.err
+		}
+
+		vm.resetBudget()
 		matched, caps := vm.Run(pos)
+		if vm.err != nil {
+			return nil, vm.err
+		}
 		if matched {
-			// Build result from captures
-			result := make([]string, len(re.subexpNames))
-			for i := 0; i < len(result); i++ {
-				start, end := -1, -1
-				if 2*i < len(caps) {
-					start = caps[2*i]
-				}
-				if 2*i+1 < len(caps) {
-					end = caps[2*i+1]
-				}
-				if start >= 0 && end >= 0 && end >= start {
-					result[i] = s[start:end]
-				}
-			}
-			return result
+			return caps, nil
 		}
 
 		_, w := input.Step(pos)
@@ -142,195 +259,181 @@ func (re *Regexp) FindStringSubmatch(s string) []string {
 		}
 		pos += w
 	}
-	return nil
+	return nil, nil
 }
 
-func (re *Regexp) match(input Input) bool {
-	vm := NewVM(re.prog, input)
-	inputLen := input.Len()
+// findSpan is find for callers that only need where the match is, which the
+// DFA can tell them without running the Pike VM. Only the first two
+// registers of the result are set.
+func (re *Regexp) findSpan(vm *VM, pos int) ([]int, error) {
+	if s, ok := re.stdInput(vm.input); ok && pos == 0 {
+		return re.findStd(vm, s, false)
+	}
+	if re.dfaPool != nil {
+		if caps, err := re.findDFA(vm, pos); err != errDFAGaveUp {
+			return caps, err
+		}
+	}
+	return re.find(vm, pos)
+}
+
+// findAll calls deliver with the registers of each successive match, at most
+// n times if n >= 0. Each match has a budget of its own. Unless submatches
+// is set, only the first two registers are set.
+func (re *Regexp) findAll(ctx context.Context, input Input, n int, submatches bool, deliver func(caps []int)) error {
+	vm := re.newVM(ctx, input)
+	if s, ok := re.stdInput(input); ok && !re.std.empty {
+		return re.findAllStd(vm, s, n, submatches, deliver)
+	}
 
+	find := re.findSpan
+	if submatches {
+		find = re.find
+	}
 	pos := 0
-	for pos <= inputLen {
-		// Use prefix search to skip impossible positions
-		if re.prog.Prefix != "" && pos < inputLen {
-			prefixPos := input.Index(re, pos)
-			if prefixPos == -1 {
-				return false // No prefix found anywhere
+	for count := 0; n < 0 || count < n; count++ {
+		caps, err := find(vm, pos)
+		if err != nil {
+			return err
+		}
+		if caps == nil {
+			break
+		}
+		deliver(caps)
+
+		// Advance past this match (handle zero-width matches)
+		matchEnd := caps[1]
+		if matchEnd == caps[0] {
+			// Zero-width match, advance by one rune
+			_, w := input.Step(matchEnd)
+			if w == 0 {
+				break
 			}
-			pos = prefixPos
+			pos = matchEnd + w
+		} else {
+			pos = matchEnd
 		}
+	}
+	return nil
+}
 
-		matched, _ := vm.Run(pos)
-		if matched {
-			return true
+// submatches returns the text of each group in caps, with "" for groups that
+// did not take part in the match.
+func (re *Regexp) submatches(s string, caps []int) []string {
+	result := make([]string, len(re.subexpNames))
+	for i := 0; i < len(result); i++ {
+		start, end := -1, -1
+		if 2*i < len(caps) {
+			start = caps[2*i]
 		}
-		_, w := input.Step(pos)
-		if w == 0 {
-			break
+		if 2*i+1 < len(caps) {
+			end = caps[2*i+1]
+		}
+		if start >= 0 && end >= 0 && end >= start {
+			result[i] = s[start:end]
 		}
-		pos += w
 	}
-	return false
+	return result
 }
 
 // FindString returns the leftmost match of the regular expression in s.
 // Returns empty string if no match found.
 func (re *Regexp) FindString(s string) string {
-	match := re.FindStringIndex(s)
+	match, _ := re.FindStringErr(s)
+	return match
+}
+
+// FindStringErr is like FindString but returns an error wrapping
+// ErrMatchLimit if the search exceeds the limits.
+func (re *Regexp) FindStringErr(s string) (string, error) {
+	return re.FindStringContext(context.Background(), s)
+}
+
+// FindStringContext is like FindStringErr but also stops, returning
+// ctx.Err(), once ctx is done.
+func (re *Regexp) FindStringContext(ctx context.Context, s string) (string, error) {
+	match, err := re.FindStringIndexContext(ctx, s)
 	if match == nil {
-		return ""
+		return "", err
 	}
-	return s[match[0]:match[1]]
+	return s[match[0]:match[1]], nil
 }
 
 // FindStringIndex returns a two-element slice of integers defining the location
 // of the leftmost match in s. Returns nil if no match found.
 func (re *Regexp) FindStringIndex(s string) []int {
-	input := NewStringInput(s)
-	vm := NewVM(re.prog, input)
-
-	pos := 0
-	inputLen := input.Len()
-	for pos <= inputLen {
-		// Use prefix search if available
-		if re.prog.Prefix != "" && pos < inputLen {
-			prefixPos := input.Index(re, pos)
-			if prefixPos == -1 {
-				return nil
-			}
-			pos = prefixPos
-		}
+	match, _ := re.FindStringIndexErr(s)
+	return match
+}
 
-		matched, caps := vm.Run(pos)
-		if matched && len(caps) >= 2 {
-			return []int{caps[0], caps[1]} // Return [start, end] of whole match
-		}
+// FindStringIndexErr is like FindStringIndex but returns an error wrapping
+// ErrMatchLimit if the search exceeds the limits.
+func (re *Regexp) FindStringIndexErr(s string) ([]int, error) {
+	return re.FindStringIndexContext(context.Background(), s)
+}
 
-		_, w := input.Step(pos)
-		if w == 0 {
-			break
-		}
-		pos += w
+// FindStringIndexContext is like FindStringIndexErr but also stops,
+// returning ctx.Err(), once ctx is done.
+func (re *Regexp) FindStringIndexContext(ctx context.Context, s string) ([]int, error) {
+	caps, err := re.findSpan(re.newVM(ctx, NewStringInput(s)), 0)
+	if caps == nil {
+		return nil, err
 	}
-	return nil
+	return []int{caps[0], caps[1]}, nil // Return [start, end] of whole match
 }
 
 // FindAllStringSubmatch returns a slice of all successive matches of the expression,
 // as defined by FindStringSubmatch. n < 0 means return all matches.
 func (re *Regexp) FindAllStringSubmatch(s string, n int) [][]string {
-	if n == 0 {
-		return nil
-	}
-
-	var results [][]string
-	input := NewStringInput(s)
-	inputLen := input.Len()
-	pos := 0
-
-	for (n < 0 || len(results) < n) && pos <= inputLen {
-		vm := NewVM(re.prog, input)
+	matches, _ := re.FindAllStringSubmatchErr(s, n)
+	return matches
+}
 
-		// Prefix optimization
-		if re.prog.Prefix != "" && pos < inputLen {
-			prefixPos := input.Index(re, pos)
-			if prefixPos == -1 {
-				break
-			}
-			pos = prefixPos
-		}
+// FindAllStringSubmatchErr is like FindAllStringSubmatch but returns an error
+// wrapping ErrMatchLimit, and the matches found before it, if the search for
+// a match exceeds the limits.
+func (re *Regexp) FindAllStringSubmatchErr(s string, n int) ([][]string, error) {
+	return re.FindAllStringSubmatchContext(context.Background(), s, n)
+}
 
-		matched, caps := vm.Run(pos)
-		if matched {
-			// Build result from captures
-			result := make([]string, len(re.subexpNames))
-			for i := 0; i < len(result); i++ {
-				start, end := -1, -1
-				if 2*i < len(caps) {
-					start = caps[2*i]
-				}
-				if 2*i+1 < len(caps) {
-					end = caps[2*i+1]
-				}
-				if start >= 0 && end >= 0 && end >= start {
-					result[i] = s[start:end]
-				}
-			}
-			results = append(results, result)
-
-			// Advance past this match (handle zero-width matches)
-			matchEnd := caps[1]
-			if matchEnd == pos {
-				// Zero-width match, advance by one rune
-				_, w := input.Step(pos)
-				if w == 0 {
-					break
-				}
-				pos += w
-			} else {
-				pos = matchEnd
-			}
-		} else {
-			_, w := input.Step(pos)
-			if w == 0 {
-				break
-			}
-			pos += w
-		}
+// FindAllStringSubmatchContext is like FindAllStringSubmatchErr but also
+// stops, returning ctx.Err() and the matches found so far, once ctx is done.
+func (re *Regexp) FindAllStringSubmatchContext(ctx context.Context, s string, n int) ([][]string, error) {
+	if n == 0 {
+		return nil, nil
 	}
-
-	return results
+	var results [][]string
+	err := re.findAll(ctx, NewStringInput(s), n, true, func(caps []int) {
+		results = append(results, re.submatches(s, caps))
+	})
+	return results, err
 }
 
 // FindAllStringIndex returns a slice of all successive matches of the expression,
 // as two-element slices of integers. n < 0 means return all matches.
 func (re *Regexp) FindAllStringIndex(s string, n int) [][]int {
-	if n == 0 {
-		return nil
-	}
-
-	var results [][]int
-	input := NewStringInput(s)
-	inputLen := input.Len()
-	pos := 0
-
-	for (n < 0 || len(results) < n) && pos <= inputLen {
-		vm := NewVM(re.prog, input)
+	matches, _ := re.FindAllStringIndexErr(s, n)
+	return matches
+}
 
-		// Prefix optimization
-		if re.prog.Prefix != "" && pos < inputLen {
-			prefixPos := input.Index(re, pos)
-			if prefixPos == -1 {
-				break
-			}
-			pos = prefixPos
-		}
+// FindAllStringIndexErr is like FindAllStringIndex but returns an error
+// wrapping ErrMatchLimit, and the matches found before it, if the search for
+// a match exceeds the limits.
+func (re *Regexp) FindAllStringIndexErr(s string, n int) ([][]int, error) {
+	return re.FindAllStringIndexContext(context.Background(), s, n)
+}
 
-		matched, caps := vm.Run(pos)
-		if matched && len(caps) >= 2 {
-			results = append(results, []int{caps[0], caps[1]})
-
-			// Advance past this match (handle zero-width matches)
-			matchEnd := caps[1]
-			if matchEnd == pos {
-				// Zero-width match, advance by one rune
-				_, w := input.Step(pos)
-				if w == 0 {
-					break
-				}
-				pos += w
-			} else {
-				pos = matchEnd
-			}
-		} else {
-			_, w := input.Step(pos)
-			if w == 0 {
-				break
-			}
-			pos += w
-		}
+// FindAllStringIndexContext is like FindAllStringIndexErr but also stops,
+// returning ctx.Err() and the matches found so far, once ctx is done.
+func (re *Regexp) FindAllStringIndexContext(ctx context.Context, s string, n int) ([][]int, error) {
+	if n == 0 {
+		return nil, nil
 	}
-
-	return results
+	var results [][]int
+	err := re.findAll(ctx, NewStringInput(s), n, false, func(caps []int) {
+		results = append(results, []int{caps[0], caps[1]})
+	})
+	return results, err
 }
 
 // Split slices s into substrings separated by the expression and returns a slice of
@@ -0,0 +1,54 @@
+package gore
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestAbsentOperator tests (?~...), which matches the longest string not containing the body
+func TestAbsentOperator(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    []string
+	}{
+		// C comments
+		{`/\*(?~\*/)\*/`, "/* a */ b */", []string{"/* a */"}},
+		{`/\*(?~\*/)\*/`, "x /**/ y", []string{"/**/"}},
+		{`/\*(?~\*/)\*/`, "/* * / */", []string{"/* * / */"}},
+		{`/\*(?~\*/)\*/`, "/* unterminated", nil},
+
+		// Greedy: the longest string that avoids the body
+		{`(?~abc)`, "xxabcyy", []string{"xxab"}},
+		{`^(?~abc)$`, "xxabyy", []string{"xxabyy"}},
+		{`^(?~abc)$`, "xxabcyy", nil},
+		{`(?~a)`, "bbbab", []string{"bbb"}},
+
+		// Backtracks to shorter strings when the rest of the pattern needs it
+		{`(?~abc)c`, "abcc", []string{"abc"}},
+		{`"(?~")"`, `say "hi" and "bye"`, []string{`"hi"`}},
+		{`((?~ab))b`, "aab", []string{"aab", "aa"}},
+
+		// A body that matches the empty string is in every string
+		{`x(?~)`, "x", nil},
+		{`x(?~a*)`, "x", nil},
+
+		// Alternatives and classes in the body
+		{`(?~foo|bar)`, "abcbarfoo", []string{"abcba"}},
+		{`(?~\d)`, "abc1", []string{"abc"}},
+
+		// Multibyte text
+		{`(?~終)`, "始め終わり", []string{"始め"}},
+
+		// Inside a lookbehind
+		{`(?<=^(?~b))x`, "aax", []string{"x"}},
+		{`(?<=^(?~b))x`, "abx", nil},
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		got := re.FindStringSubmatch(tc.input)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("FindStringSubmatch(%q, %q) = %q; want %q", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package gore
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -137,3 +138,141 @@ func TestMatchReader(t *testing.T) {
 		t.Error("MatchReader failed to match")
 	}
 }
+
+// TestLookaroundCaptures tests that groups inside lookarounds are visible to the outer match
+func TestLookaroundCaptures(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    []string
+	}{
+		// Positive assertions keep their captures
+		{`(?=(\d+))\w`, "ab123", []string{"1", "123"}},
+		{`(?<=(\w))x`, "ax", []string{"x", "a"}},
+		{`a(?=(b)(c))`, "abc", []string{"a", "b", "c"}},
+
+		// Negative assertions discard theirs
+		{`(?!(a)b)(\w)`, "ac", []string{"a", "", "a"}},
+		{`(?=(a)(?!(b)c))`, "abd", []string{"", "a", ""}},
+
+		// Backtracking past an assertion discards its captures too
+		{`(?:(?=(a))x|a)(b)`, "ab", []string{"ab", "", "b"}},
+		{`(?:(*atomic_script_run:(a)b?)x|ab)`, "ab", []string{"ab", ""}},
+
+		// Backreferences to groups captured inside an assertion
+		{`(?=(\w+)@)\1@`, "user@host", []string{"user@", "user"}},
+		{`^(?=(a+))a*b\1`, "aaab", nil},
+		{`(?=(a+?))(\1)`, "aaa", []string{"a", "a", "a"}},
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		got := re.FindStringSubmatch(tc.input)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("FindStringSubmatch(%q, %q) = %q; want %q", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+}
+
+// TestNonAtomicLookaround tests (?*...) and (?<*...), which can be backtracked into
+func TestNonAtomicLookaround(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    []string
+	}{
+		// Atomic assertions commit to their first match
+		{`^(?=(a+))a*b\1`, "aaaba", nil},
+		{`(?<=(a+))b\1`, "aaaba", nil},
+
+		// Non-atomic assertions retry shorter captures when the rest fails
+		{`^(?*(a+))a*b\1`, "aaaba", []string{"aaaba", "a"}},
+		{`^(*napla:(a+))a*b\1`, "aaaba", []string{"aaaba", "a"}},
+		{`^(*non_atomic_positive_lookahead:(a+))a*b\1`, "aaaba", []string{"aaaba", "a"}},
+		{`(?<*(a+))b\1`, "aaaba", []string{"ba", "a"}},
+		{`(*naplb:(a+))b\1`, "aaaba", []string{"ba", "a"}},
+
+		// Still zero-width
+		{`(?*a)ab`, "ab", []string{"ab"}},
+		{`(?<*b)x`, "bx", []string{"x"}},
+		{`(?*b)`, "a", nil},
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		got := re.FindStringSubmatch(tc.input)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("FindStringSubmatch(%q, %q) = %q; want %q", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+}
+
+// TestAlphaAssertions tests the (*name:...) spellings of lookarounds
+func TestAlphaAssertions(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		match   bool
+	}{
+		{"q(*pla:u)", "quit", true},
+		{"q(*positive_lookahead:u)", "qatar", false},
+		{"q(*nla:u)", "qatar", true},
+		{"q(*negative_lookahead:u)", "quit", false},
+		{"(*plb:foo)bar", "foobar", true},
+		{"(*positive_lookbehind:foo)bar", "bar", false},
+		{"(*nlb:a)b", "cb", true},
+		{"(*negative_lookbehind:a)b", "ab", false},
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		if got := re.MatchString(tc.input); got != tc.match {
+			t.Errorf("MatchString(%q, %q) = %v; want %v", tc.pattern, tc.input, got, tc.match)
+		}
+	}
+
+	for _, pattern := range []string{"(*bogus:a)", "(*pla", "(*)"} {
+		if _, err := Compile(pattern); err == nil {
+			t.Errorf("Compile(%q) should fail", pattern)
+		}
+	}
+}
+
+// TestReverseLookbehind tests lookbehind bodies that run backwards from the current position
+func TestReverseLookbehind(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    []string
+	}{
+		// Multibyte text
+		{`(?<=é)x`, "éx", []string{"x"}},
+		{`(?<=日本)語`, "日本語", []string{"語"}},
+		{`(?<!日)本`, "日本", nil},
+		{`(?<=.)b`, "éb", []string{"b"}},
+
+		// Variable length
+		{`(?<=a+)b`, "xaaab", []string{"b"}},
+		{`(?<=ab|c)d`, "abd", []string{"d"}},
+		{`(?<=ab|c)d`, "cd", []string{"d"}},
+		{`(?<=ab|c)d`, "bd", nil},
+		{`(?<=\d{2,3})x`, "12x", []string{"x"}},
+
+		// Captures and nested assertions
+		{`(?<=(\w+)-)x`, "ab-x", []string{"x", "ab"}},
+		{`(?<=(a)(b))c`, "abc", []string{"c", "a", "b"}},
+		{`(?<=a(?=b)b)c`, "abc", []string{"c"}},
+		{`(?<=(?<=a)b)c`, "abc", []string{"c"}},
+		{`(?<=(?<=x)b)c`, "abc", nil},
+		{`(\w)(?<=\1)`, "a", []string{"a", "a"}},
+
+		// Anchors are checked at the position reached going backwards
+		{`(?<=^a)b`, "ab", []string{"b"}},
+		{`(?<=^a)b`, "aab", nil},
+		{`(?<*^(a+))b`, "aab", []string{"b", "aa"}},
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		got := re.FindStringSubmatch(tc.input)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("FindStringSubmatch(%q, %q) = %q; want %q", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+}
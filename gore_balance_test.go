@@ -0,0 +1,193 @@
+package gore
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestNamedGroupSyntax tests the (?<name>...) and (?'name'...) spellings
+func TestNamedGroupSyntax(t *testing.T) {
+	for _, pattern := range []string{`(?<year>\d{4})-(?<month>\d{2})`, `(?'year'\d{4})-(?'month'\d{2})`} {
+		re := MustCompile(pattern)
+		if got, want := re.SubexpNames(), []string{"", "year", "month"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("SubexpNames(%q) = %q; want %q", pattern, got, want)
+		}
+		if got, want := re.FindStringSubmatch("on 2024-05"), []string{"2024-05", "2024", "05"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("FindStringSubmatch(%q) = %q; want %q", pattern, got, want)
+		}
+	}
+}
+
+// TestBalancingGroups tests .NET balancing groups and capture stacks
+func TestBalancingGroups(t *testing.T) {
+	// Balanced parentheses
+	parens := MustCompile(`^(?:[^()]|(?<open>\()|(?<-open>\)))*(?(open)(?!))$`)
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"", true},
+		{"()", true},
+		{"(a(b)c)", true},
+		{"(()())", true},
+		{"(a(b)c", false},
+		{"a)b(", false},
+		{"())(", false},
+	}
+	for _, tc := range tests {
+		if got := parens.MatchString(tc.input); got != tc.want {
+			t.Errorf("balanced parens MatchString(%q) = %v; want %v", tc.input, got, tc.want)
+		}
+	}
+
+	// The receiving group captures the text between the pair
+	brackets := MustCompile(`^[^<>]*(((?'Open'<)[^<>]*)+((?'Close-Open'>)[^<>]*)+)*(?(Open)(?!))$`)
+	m := brackets.FindStringSubmatch("<abc><mno<xyz>>")
+	if m == nil {
+		t.Fatal("balanced brackets did not match")
+	}
+	if got := m[brackets.SubexpIndex("Close")]; got != "mno<xyz>" {
+		t.Errorf("Close = %q; want %q", got, "mno<xyz>")
+	}
+	if got := m[brackets.SubexpIndex("Open")]; got != "" {
+		t.Errorf("Open = %q; want empty after balancing", got)
+	}
+	if brackets.MatchString("<abc><mno<xyz>") {
+		t.Error("unbalanced brackets should not match")
+	}
+
+	// Popping shows the previous capture
+	re := MustCompile(`(?<a>\w)+(?<-a>!)`)
+	if got, want := re.FindStringSubmatch("xyz!"), []string{"xyz!", "y"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindStringSubmatch = %q; want %q", got, want)
+	}
+
+	// Popping an empty stack fails
+	if MustCompile(`^(?<a>x)?(?<-a>y)`).MatchString("y") {
+		t.Error("pop of an empty capture stack should fail")
+	}
+}
+
+// TestConditionalGroups tests (?(group)yes|no)
+func TestConditionalGroups(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    string
+	}{
+		{`(a)?(?(1)b|c)`, "ab", "ab"},
+		{`(a)?(?(1)b|c)`, "c", "c"},
+		{`(a)?(?(1)b|c)`, "ac", "c"},
+		{`(?<q>")?\w+(?(q)")`, `"hi"`, `"hi"`},
+		{`(?<q>")?\w+(?(<q>)")`, `hi"`, `hi`},
+		{`(?<q>")?\w+(?('q')")`, `"hi`, `hi`},
+		{`^(x)?(?(1)y)$`, "", ""},
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		if got := re.FindString(tc.input); got != tc.want {
+			t.Errorf("FindString(%q, %q) = %q; want %q", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+
+	for _, pattern := range []string{`(?(nope)a)`, `(a)(?(1)a|b|c)`, `(?<-nope>a)`, `(?(1)a`} {
+		if _, err := Compile(pattern); err == nil {
+			t.Errorf("Compile(%q) should fail", pattern)
+		}
+	}
+}
+
+// TestDuplicateNames tests groups sharing a name under (?J) and DupNames
+func TestDuplicateNames(t *testing.T) {
+	if _, err := Compile(`(?<d>a)|(?<d>b)`); err == nil {
+		t.Error("duplicate names without (?J) should not compile")
+	}
+
+	dates := []*Regexp{
+		MustCompile(`(?J)(?<d>\d{4})-..|..(?<d>\d{4})`),
+		MustCompileFlags(`(?<d>\d{4})-..|..(?<d>\d{4})`, DupNames),
+	}
+	for _, re := range dates {
+		if got := re.NumSubexp(); got != 2 {
+			t.Errorf("%q: NumSubexp() = %d; want 2", re, got)
+		}
+		for input, want := range map[string]string{"2024-05": "2024", "5/2024": "2024"} {
+			if got := re.ReplaceAllString(input, "${d}"); got != want {
+				t.Errorf("%q on %q: d = %q; want %q", re, input, got, want)
+			}
+		}
+		if got, want := re.ReplaceAllString("5/2024", "year ${d}"), "year 2024"; got != want {
+			t.Errorf("%q: ReplaceAllString = %q; want %q", re, got, want)
+		}
+	}
+
+	tests := []struct {
+		pattern string
+		input   string
+		want    []string
+	}{
+		// Each group has a number of its own, counted as usual
+		{`(?J)(?<d>a)|(?<d>b)(c)`, "bc", []string{"bc", "", "b", "c"}},
+		{`(?J)(?<n>a(?<n>b))`, "ab", []string{"ab", "ab", "b"}},
+		{`(?J)(?<n>a)(?<n>b)`, "ab", []string{"ab", "a", "b"}},
+		// The backreference follows the first group of the name that is set
+		{`(?J)(?:(?<c>a)|(?<c>b))\k<c>`, "bb", []string{"bb", "", "b"}},
+		{`(?J)(?:(?<c>a)|(?<c>b))\k<c>`, "ab", nil},
+		{`(?J)^(?<c>a)?(?<c>b)\k<c>`, "abb", nil},
+		{`(?J)^(?<c>a)?(?<c>b)\k<c>`, "aba", []string{"aba", "a", "b"}},
+		// So does a condition on the name
+		{`(?J)(?:(?<c>a)|(?<c>b))(?(<c>)x|y)`, "bx", []string{"bx", "", "b"}},
+		// (?-J) turns duplicates off again for the rest of the pattern
+		{`(?J:(?<x>a)|(?<x>b))`, "b", []string{"b", "", "b"}},
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		if got := re.FindStringSubmatch(tc.input); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("FindStringSubmatch(%q, %q) = %q; want %q", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+	re := MustCompile(`(?J)(?<d>a)|(?<d>b)(c)`)
+	if got, want := re.SubexpNames(), []string{"", "d", "d", ""}; re.NumSubexp() != 3 || !reflect.DeepEqual(got, want) {
+		t.Errorf("%q: NumSubexp() = %d, SubexpNames() = %q; want 3, %q", re, re.NumSubexp(), got, want)
+	}
+	if got := re.SubexpIndex("d"); got != 1 {
+		t.Errorf("%q: SubexpIndex(\"d\") = %d; want 1", re, got)
+	}
+	if _, err := Compile(`(?J:(?<x>a))(?<x>b)`); err == nil {
+		t.Error("duplicate name outside the (?J:...) group should not compile")
+	}
+
+	// Each group of a shared name has a stack of its own, so a balancing
+	// group cannot pop the name
+	for _, pattern := range []string{
+		`(?J)^(?:(?<o>\()|(?<o>\[)|(?<-o>[\])]))*(?(o)(?!))$`,
+		`(?J)(?<o>a)(?<-o>b)(?<o>c)`,
+	} {
+		var e *Error
+		if _, err := Compile(pattern); !errors.As(err, &e) || e.Code != ErrUnsupported {
+			t.Errorf("Compile(%q) error = %v; want %q", pattern, err, ErrUnsupported)
+		}
+	}
+}
+
+// TestNamedBackreferences tests \k<name>, \k'name', \k{name} and (?P=name)
+func TestNamedBackreferences(t *testing.T) {
+	for _, pattern := range []string{
+		`(?<q>["'])\w+\k<q>`,
+		`(?<q>["'])\w+\k'q'`,
+		`(?<q>["'])\w+\k{q}`,
+		`(?P<q>["'])\w+(?P=q)`,
+	} {
+		re := MustCompile(pattern)
+		if !re.MatchString(`say "hi"`) {
+			t.Errorf("%q should match a quoted word", pattern)
+		}
+		if re.MatchString(`say "hi'`) {
+			t.Errorf("%q should not match mismatched quotes", pattern)
+		}
+	}
+	if _, err := Compile(`\k<missing>`); err == nil {
+		t.Error("backreference to an undefined name should not compile")
+	}
+}
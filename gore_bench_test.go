@@ -32,8 +32,8 @@ func BenchmarkLookbehind(b *testing.B) {
 	}
 }
 
-// BenchmarkLookbehindLong checks performance scaling of the naive O(N) lookbehind.
-// Since it scans from the start for every position, a long prefix should hurt.
+// BenchmarkLookbehindLongPrefix checks that lookbehind cost does not scale with the
+// distance from the start of the input, since the body runs backwards from pos.
 func BenchmarkLookbehindLongPrefix(b *testing.B) {
 	re := MustCompile(`(?<=foo)bar`)
 	payload := strings.Repeat("x", 1000)
@@ -166,3 +166,64 @@ func BenchmarkQuantifierPlus(b *testing.B) {
 		re.MatchString(input)
 	}
 }
+
+// BenchmarkManyGroups benchmarks backtracking through a pattern with many
+// capture groups, where restoring the registers dominates.
+func BenchmarkManyGroups(b *testing.B) {
+	re := MustCompile(`(a)?(b)?(c)?(d)?(e)?(f)?(g)?(h)?(i)?(j)?(\w+)z`)
+	input := strings.Repeat("abcdefghij", 5) + "!"
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		re.MatchString(input)
+	}
+}
+
+// BenchmarkValidation benchmarks an anchored validation pattern, which runs
+// on the one-pass engine.
+func BenchmarkValidation(b *testing.B) {
+	re := MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	input := "2024-06-30"
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		re.FindStringSubmatch(input)
+	}
+}
+
+// BenchmarkBacktrackSteps benchmarks a long search on the backtracking
+// engine, where the cost of each VM step dominates.
+func BenchmarkBacktrackSteps(b *testing.B) {
+	re := MustCompile(`(\w+)\s+(\w+)(?=;)`)
+	input := strings.Repeat("alpha beta gamma delta ", 20) + "x y;"
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		re.MatchString(input)
+	}
+}
+
+// BenchmarkPrefilter benchmarks searches for patterns without a literal
+// prefix through a long input, with the match at the end.
+func BenchmarkPrefilter(b *testing.B) {
+	input := strings.Repeat("info: request served in 12ms from cache; ", 100)
+	for _, bc := range []struct {
+		name, pattern, match string
+		opts                 Options
+	}{
+		{"Alternation", `(GET|POST) /`, "POST /", Options{NoStdlib: true}},
+		{"FoldCase", `(?i)error`, "Error", Options{NoStdlib: true}},
+		{"Class", `[A-Z]\w+`, "Done", Options{NoStdlib: true}},
+		{"Backtrack", `(?i)error(?=:)`, "ERROR:", Options{}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			re := MustCompileWithOptions(bc.pattern, bc.opts)
+			s := input + bc.match
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				re.FindStringIndex(s)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package gore
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 // TestInvalidPatterns tests that invalid regex patterns produce errors
 func TestInvalidPatterns(t *testing.T) {
@@ -76,3 +79,158 @@ func TestValidEdgeCasePatterns(t *testing.T) {
 		}
 	}
 }
+
+// TestErrorDetails tests the code and offset of compile errors
+func TestErrorDetails(t *testing.T) {
+	tests := []struct {
+		pattern string
+		code    ErrorCode
+		offset  int
+	}{
+		{"ab(cd", ErrMissingParen, 2},
+		{"(a(?:b)", ErrMissingParen, 0},
+		{"a(?=b", ErrMissingParen, 1},
+		{"ab)", ErrUnexpectedParen, 2},
+		{"x[abc", ErrMissingBracket, 1},
+		{"ab\\", ErrTrailingBackslash, 2},
+		{"[a-cz-a]", ErrInvalidCharRange, 4},
+		{"[\\D]", ErrInvalidEscape, 1},
+		{"ab|*", ErrMissingRepeatArgument, 3},
+		{"a{3,2}", ErrInvalidRepeatSize, 1},
+		{"a{x}", ErrInvalidRepeat, 1},
+		{"(?iq)", ErrInvalidFlag, 3},
+		{"(?P<1x>a)", ErrInvalidGroupName, 4},
+		{"(?<a>x)(?<a>y)", ErrDuplicateGroupName, 10},
+		{"(a)\\k<b>", ErrInvalidGroupRef, 6},
+		{"(?(x)a|b)", ErrInvalidGroupRef, 3},
+		{"(?<-5>a)", ErrInvalidGroupRef, 4},
+		{"(?<x-5>a)", ErrInvalidGroupRef, 5},
+		{"(a)(?(1)a|b|c)", ErrInvalidConditional, 11},
+		{"(*FOO)a", ErrInvalidOptionItem, 0},
+		{"(?Q)", ErrInvalidGroup, 2},
+	}
+	for _, tc := range tests {
+		_, err := Compile(tc.pattern)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("Compile(%q) error = %v; want *Error", tc.pattern, err)
+			continue
+		}
+		if e.Code != tc.code || e.Offset != tc.offset {
+			t.Errorf("Compile(%q) = %q at %d; want %q at %d", tc.pattern, e.Code, e.Offset, tc.code, tc.offset)
+		}
+		if e.Pattern != tc.pattern {
+			t.Errorf("Compile(%q) Pattern = %q", tc.pattern, e.Pattern)
+		}
+	}
+}
+
+// TestErrorCaret tests rendering a caret under the problem
+func TestErrorCaret(t *testing.T) {
+	_, err := Compile(`(?<year>\d{4})-(?<year>\d{2})`)
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("error = %v; want *Error", err)
+	}
+	want := "(?<year>\\d{4})-(?<year>\\d{2})\n                  ^"
+	if got := e.Caret(); got != want {
+		t.Errorf("Caret() =\n%s\nwant\n%s", got, want)
+	}
+
+	// The caret counts runes, not bytes, and stays on the right line
+	e = &Error{Pattern: "héllo\nwo(rld", Offset: 9}
+	if got, want := e.Caret(), "wo(rld\n  ^"; got != want {
+		t.Errorf("Caret() = %q; want %q", got, want)
+	}
+}
+
+// TestStrictEscapes tests that unknown escapes and unsupported syntax are
+// rejected unless the Lenient flag is set
+func TestStrictEscapes(t *testing.T) {
+	tests := []struct {
+		pattern string
+		code    ErrorCode
+		offset  int
+	}{
+		{`a\p{L}`, ErrUnsupported, 1},
+		{`\x41`, ErrUnsupported, 0},
+		{`\Qa.b\E`, ErrUnsupported, 0},
+		{`\k`, ErrInvalidEscape, 0},
+		{`\0`, ErrUnsupported, 0},
+		{`\y`, ErrInvalidEscape, 0},
+		{`[a\q]`, ErrInvalidEscape, 2},
+		{`[\x41]`, ErrUnsupported, 1},
+		{`[[:alpha:]]`, ErrUnsupported, 1},
+		{`a(?>b)`, ErrUnsupported, 2},
+		{`(?|a)`, ErrUnsupported, 1},
+		{`(?#note)a`, ErrUnsupported, 1},
+		{`(a)(?1)`, ErrUnsupported, 4},
+		{`(a)(?-1)`, ErrUnsupported, 4},
+		{`(?-12)`, ErrUnsupported, 1},
+		{`(?R)`, ErrUnsupported, 1},
+		{`(?P>n)`, ErrUnsupported, 1},
+		{`(?X)a`, ErrUnsupported, 1},
+		{`(?iX)a`, ErrUnsupported, 3},
+		{`(?(?=a)a|b)`, ErrUnsupported, 3},
+		{`(?(DEFINE)(?<d>a))`, ErrUnsupported, 3},
+		{`a*+`, ErrUnsupported, 2},
+		{`a{2}+`, ErrUnsupported, 4},
+		{`a(*FAIL)|b`, ErrUnsupported, 1},
+		{`a(*SKIP)b`, ErrUnsupported, 1},
+		{`(*CRLF)a`, ErrUnsupported, 0},
+		{`(*COMMIT)a`, ErrUnsupported, 0},
+		{`(?<=(\w)\1)x`, ErrUnsupported, 8},
+		{`a(?<=\1(a))x`, ErrUnsupported, 5},
+		{`(?<*(?<n>a)\k<n>)x`, ErrUnsupported, 11},
+		{`(?<=(?P<n>a)(?P=n))x`, ErrUnsupported, 12},
+		{`(*plb:(a)(?=\1))x`, ErrUnsupported, 12},
+		{`(?~(a)\1)`, ErrUnsupported, 6},
+		{`x(?~a(?<n>b)+\k<n>)`, ErrUnsupported, 13},
+	}
+	for _, tc := range tests {
+		_, err := Compile(tc.pattern)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("Compile(%q) error = %v; want *Error", tc.pattern, err)
+			continue
+		}
+		if e.Code != tc.code || e.Offset != tc.offset {
+			t.Errorf("Compile(%q) = %q at %d (%v); want %q at %d", tc.pattern, e.Code, e.Offset, e, tc.code, tc.offset)
+		}
+	}
+
+	// Known escapes and escaped punctuation still work
+	for _, pattern := range []string{`\d\w\s\b\B\A\z\Z\n\t\r\f\v`, `\.\-\/\#\ \@`, `[\-\]\\\n\d]`, `(a)\1`} {
+		if _, err := Compile(pattern); err != nil {
+			t.Errorf("Compile(%q) error: %v", pattern, err)
+		}
+	}
+
+	// Inside a class, \b is a backspace
+	if !MustCompile(`^[\b]$`).MatchString("\b") {
+		t.Error(`[\b] should match a backspace`)
+	}
+
+	// Lenient mode keeps the old literal behavior for escapes
+	lenient := []struct {
+		pattern string
+		input   string
+		want    bool
+	}{
+		{`\y`, "y", true},
+		{`\k`, "k", true},
+		{`[\q]`, "q", true},
+		{`[[:alpha:]]`, "a]", true},
+		{`^[\b]$`, "b", true},
+	}
+	for _, tc := range lenient {
+		re, err := CompileFlags(tc.pattern, Lenient)
+		if err != nil {
+			t.Errorf("CompileFlags(%q, Lenient) error: %v", tc.pattern, err)
+			continue
+		}
+		if got := re.MatchString(tc.input); got != tc.want {
+			t.Errorf("lenient MatchString(%q, %q) = %v; want %v", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+}
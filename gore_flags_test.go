@@ -1,6 +1,10 @@
 package gore
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
 
 // TestCaseInsensitive tests the (?i) flag
 func TestCaseInsensitive(t *testing.T) {
@@ -132,3 +136,95 @@ func TestDotallMode(t *testing.T) {
 		}
 	}
 }
+
+// TestNoAutoCapture tests the (?n) flag and the NoAutoCapture compile flag
+func TestNoAutoCapture(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    []string
+	}{
+		{`(?n)(a)(?<x>b)(c)`, "abc", []string{"abc", "b"}},
+		{`(?n)(a|b)+`, "abab", []string{"abab"}},
+		// Numbered backreferences count named groups only
+		{`(?n)(?<x>a)(-)\1`, "a-a", []string{"a-a", "a"}},
+		// Scoped and negated
+		{`(?n:(a))(b)`, "ab", []string{"ab", "b"}},
+		{`(?n)(a)(?-n)(b)`, "ab", []string{"ab", "b"}},
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		if got := re.FindStringSubmatch(tc.input); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("FindStringSubmatch(%q, %q) = %q; want %q", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+
+	re := MustCompileFlags(`(\w+)@(?<host>\w+)`, NoAutoCapture)
+	if got, want := re.SubexpNames(), []string{"", "host"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SubexpNames() = %q; want %q", got, want)
+	}
+	if got, want := re.FindStringSubmatch("me@example"), []string{"me@example", "example"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindStringSubmatch() = %q; want %q", got, want)
+	}
+}
+
+// TestStartOptionItems tests the (*...) option items at the start of a pattern
+func TestStartOptionItems(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    bool
+	}{
+		// (*UCP) makes \d, \w, \s and \b Unicode aware
+		{`^\d+$`, "١٢٣", false},
+		{`(*UCP)^\d+$`, "١٢٣", true},
+		{`(*UCP)^\w+$`, "café", true},
+		{`(*UCP)^[\w\s]+$`, "naïve café", true},
+		{`(*UCP)^\s$`, " ", true},
+		{`(*UCP)^\D$`, "٣", false},
+		{`\bмир\b`, "привет мир", false},
+		{`(*UCP)\bмир\b`, "привет мир", true},
+		{`(*UCP)\bми\b`, "привет мир", false},
+		// (*UTF) changes nothing, and items combine
+		{`(*UTF)(*UCP)^\w$`, "ß", true},
+		{`(*UTF8)é`, "café", true},
+		// Alphabetic groups are not option items
+		{`(*pla:a)a`, "a", true},
+		{`(*UTF)(*pla:a)a`, "a", true},
+		// Limits turn a long match attempt into no match
+		{`(*LIMIT_MATCH=100)^a+b`, strings.Repeat("a", 200) + "b", false},
+		{`(*LIMIT_MATCH=1000)^a+b`, "aaab", true},
+		{`(*LIMIT_DEPTH=10)^a*b`, strings.Repeat("a", 20) + "b", false},
+		{`(*LIMIT_DEPTH=30)^a*b`, strings.Repeat("a", 20) + "b", true},
+		{`(*LIMIT_DEPTH=10)(*LIMIT_DEPTH=1000)^a*b`, strings.Repeat("a", 20) + "b", false},
+		{`(*NO_START_OPT)abc`, "xxabc", true},
+	}
+	for _, tc := range tests {
+		// The limits only apply to the backtracking engine
+		re, err := CompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack})
+		if err != nil {
+			t.Errorf("Compile(%q) error: %v", tc.pattern, err)
+			continue
+		}
+		if got := re.MatchString(tc.input); got != tc.want {
+			t.Errorf("MatchString(%q, %q) = %v; want %v", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+
+	if prefix, _ := MustCompile(`(*NO_START_OPT)abc`).LiteralPrefix(); prefix != "" {
+		t.Errorf("(*NO_START_OPT) LiteralPrefix() = %q; want none", prefix)
+	}
+
+	for _, pattern := range []string{
+		`(*FOO)a`,
+		`(*LIMIT_MATCH)a`,
+		`(*LIMIT_MATCH=0)a`,
+		`(*LIMIT_DEPTH=x)a`,
+		`(*UCP=1)a`,
+		`(*UTF`,
+	} {
+		if _, err := Compile(pattern); err == nil {
+			t.Errorf("Compile(%q) should fail", pattern)
+		}
+	}
+}
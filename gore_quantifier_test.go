@@ -1,6 +1,10 @@
 package gore
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+)
 
 // TestMatchQuantifier tests basic quantifiers *, +, ?
 func TestMatchQuantifier(t *testing.T) {
@@ -110,3 +114,51 @@ func TestNonGreedyQuantifiers(t *testing.T) {
 		}
 	}
 }
+
+// TestCountedQuantifiers tests large counts, which loop on a counter
+// instead of copying the body
+func TestCountedQuantifiers(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    string
+	}{
+		{`^a{10}`, strings.Repeat("a", 12), strings.Repeat("a", 10)},
+		{`^a{10}$`, strings.Repeat("a", 9), ""},
+		{`a{10,12}`, strings.Repeat("a", 20), strings.Repeat("a", 12)},
+		{`a{10,12}?`, strings.Repeat("a", 20), strings.Repeat("a", 10)},
+		{`a{9,}`, strings.Repeat("a", 20), strings.Repeat("a", 20)},
+		{`a{9,}?`, strings.Repeat("a", 20), strings.Repeat("a", 9)},
+		{`a{0,9}b`, "aaab", "aaab"},
+		{`^(?:a|ab){10}c$`, strings.Repeat("ab", 10) + "c", strings.Repeat("ab", 10) + "c"},
+		{`^(?:a|ab){9,10}c`, strings.Repeat("a", 5) + strings.Repeat("ab", 5) + "c", strings.Repeat("a", 5) + strings.Repeat("ab", 5) + "c"},
+		{`(?:x(?:ab){10}){2}`, "x" + strings.Repeat("ab", 10) + "x" + strings.Repeat("ab", 10), "x" + strings.Repeat("ab", 10) + "x" + strings.Repeat("ab", 10)},
+		{`(?<=a{10})b`, strings.Repeat("a", 10) + "b", "b"},
+		{`(?<=a{10})b`, strings.Repeat("a", 9) + "b", ""},
+		{`\d{100000}`, strings.Repeat("7", 100000), strings.Repeat("7", 100000)},
+		{`(?:a?){1001,}b`, "aaab", "aaab"},
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		if got := re.FindString(tc.input); got != tc.want {
+			t.Errorf("FindString(%q) = %.40q; want %.40q", tc.pattern, got, tc.want)
+		}
+	}
+
+	// Captures inside the loop are restored on backtracking
+	m := MustCompile(`^(?:(a)|(b)){9,10}b`).FindStringSubmatch("aaaaaaaabb")
+	if len(m) != 3 || m[1] != "a" || m[2] != "b" {
+		t.Errorf("FindStringSubmatch = %q; want [aaaaaaaabb a b]", m)
+	}
+
+	// The program does not grow with the count
+	if n := len(MustCompile(`\d{100000}`).prog.Insts); n > 10 {
+		t.Errorf(`\d{100000} compiles to %d instructions`, n)
+	}
+
+	var err *Error
+	_, compileErr := Compile(`(?:(?:(?:(?:(?:(?:a{8}){8}){8}){8}){8}){8}){8}`)
+	if !errors.As(compileErr, &err) || err.Code != ErrPatternTooLarge {
+		t.Errorf("nested copies: err = %v; want ErrPatternTooLarge", compileErr)
+	}
+}
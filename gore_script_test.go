@@ -0,0 +1,72 @@
+package gore
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestScriptRun tests (*sr:...) groups
+func TestScriptRun(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    bool
+	}{
+		{`^(*sr:.+)$`, "paypal", true},
+		{`^(*sr:.+)$`, "pаypal", false}, // Cyrillic а
+		{`^(*script_run:.+)$`, "Ελληνικά", true},
+		{`^(*sr:.+)$`, "hello, world!", true}, // Common punctuation fits anywhere
+		{`^(*sr:.+)$`, "e\u0301", true},       // Inherited combining mark
+		{`^(*sr:.+)$`, "", false},
+
+		// Han combines with the other scripts of Japanese, Korean and Chinese
+		{`^(*sr:.+)$`, "日本語ひらがなカタカナ", true},
+		{`^(*sr:.+)$`, "한국어漢字", true},
+		{`^(*sr:.+)$`, "注音ㄅㄆ", true},
+		{`^(*sr:.+)$`, "ひらがな한국", false},
+		{`^(*sr:.+)$`, "漢字abc", false},
+
+		// Digits must all come from the same block of ten
+		{`^(*sr:\d+)$`, "1234", true},
+		{`^(*sr:.+)$`, "١٢٣", true},
+		{`^(*sr:.+)$`, "123٤", false},
+		{`^(*sr:.+)$`, "𝟏𝟐", true},  // Mathematical bold digits
+		{`^(*sr:.+)$`, "𝟏𝟤", false}, // Bold and sans-serif digits
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		if got := re.MatchString(tc.input); got != tc.want {
+			t.Errorf("MatchString(%q, %q) = %v; want %v", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+}
+
+// TestScriptRunBacktracking tests that script runs backtrack into their body unless atomic
+func TestScriptRunBacktracking(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    []string
+	}{
+		// The body backtracks until the matched text is a script run
+		{`(*sr:.+)`, "abcΩ", []string{"abc"}},
+		{`(*sr:(.+))Ω`, "abcΩ", []string{"abcΩ", "abc"}},
+		{`(*asr:.+)`, "abcΩ", []string{"abc"}},
+
+		// Atomic script runs do not give characters back to the rest of the pattern
+		{`^(*sr:a+)a`, "aaa", []string{"aaa"}},
+		{`^(*asr:a+)a`, "aaa", nil},
+		{`^(*atomic_script_run:a+)a`, "aaa", nil},
+
+		// Inside a lookbehind
+		{`(?<=(*sr:\w\w))x`, "abx", []string{"x"}},
+		{`(?<=(*sr:..))x`, "aΩx", nil},
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		got := re.FindStringSubmatch(tc.input)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("FindStringSubmatch(%q, %q) = %q; want %q", tc.pattern, tc.input, got, tc.want)
+		}
+	}
+}
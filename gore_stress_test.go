@@ -125,3 +125,28 @@ func TestStressMultipleBackreferences(t *testing.T) {
 		t.Error("Should not match non-palindrome")
 	}
 }
+
+// TestStressRunAllocs checks that a VM reused for many searches stops
+// allocating once its stacks have grown.
+func TestStressRunAllocs(t *testing.T) {
+	patterns := []string{
+		`(a|b)*(c)`,
+		`((\w)+)\s(?=(\d))`,
+		`(x+)y|(?<!q)(\w+)$`,
+		`(?~ab)(\d)`,
+	}
+	input := strings.Repeat("ab", 50) + " 1 x" + strings.Repeat("x", 50)
+	for _, pattern := range patterns {
+		re := MustCompile(pattern)
+		vm := NewVM(re.prog, NewStringInput(input))
+		run := func() {
+			for pos := 0; pos <= len(input); pos++ {
+				vm.Run(pos)
+			}
+		}
+		run()
+		if allocs := testing.AllocsPerRun(10, run); allocs != 0 {
+			t.Errorf("%s: %v allocations per run; want 0", pattern, allocs)
+		}
+	}
+}
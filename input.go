@@ -20,7 +20,8 @@ type Input interface {
 	Len() int
 
 	// Index returns the byte index of the given string/pattern in the input starting at pos.
-	// Used for optimizations (prefix search). Returns -1 if not found.
+	// Used for optimizations (prefix search, or the prefilter when there is no prefix).
+	// Returns -1 if not found.
 	Index(re *Regexp, pos int) int
 }
 
@@ -57,7 +58,13 @@ func (s *StringInput) Len() int {
 }
 
 func (s *StringInput) Index(re *Regexp, pos int) int {
-	if re.prog.Prefix == "" || pos >= len(s.str) {
+	if pos >= len(s.str) {
+		return -1
+	}
+	if pf := re.prog.prefilter; pf != nil {
+		return prefilterIndex(pf, s.str, pos)
+	}
+	if re.prog.Prefix == "" {
 		return -1
 	}
 	// Use fast string search for literal prefix
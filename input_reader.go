@@ -44,7 +44,13 @@ func (s *ReaderInput) Len() int {
 }
 
 func (s *ReaderInput) Index(re *Regexp, pos int) int {
-	if re.prog.Prefix == "" || pos >= len(s.data) {
+	if pos >= len(s.data) {
+		return -1
+	}
+	if pf := re.prog.prefilter; pf != nil {
+		return prefilterIndex(pf, s.data, pos)
+	}
+	if re.prog.Prefix == "" {
 		return -1
 	}
 	// Use fast byte search for literal prefix
@@ -0,0 +1,166 @@
+package gore
+
+// Memoization bounds the backtracking engine the way Davis et al. describe
+// in "Using Selective Memoization to Defeat Regular Expression Denial of
+// Service (ReDoS)". Whether the program matches from instruction pc at
+// position pos does not depend on how the search got there, as long as no
+// instruction it can reach reads the registers. Reaching such a state a
+// second time therefore means it has already failed, or is being explored
+// further up the current path, and the VM can give up on it at once. Only
+// instructions with more than one way in are remembered, which is enough to
+// make every search linear in the input times the program size.
+//
+// Backreferences, conditionals, counters and the other instructions that
+// read registers make the outcome depend on more than (pc, pos), so states
+// that can reach them are never remembered. So is OpProgress, which ends
+// an iteration of a loop that can match empty when compileLoopBody cannot
+// copy the body. A pattern that ends in a backreference gains nothing,
+// while one that starts with it is memoized from there on.
+
+// DefaultMemoBudget is the memory the memoization table of a call may use
+// when Options.MemoBudget is zero.
+const DefaultMemoBudget = 32 << 20
+
+// memoPlan says which instructions of a program the backtracker remembers.
+type memoPlan struct {
+	slots  []int // Column of each instruction in the table, -1 if not remembered
+	n      int   // Columns in use
+	budget int   // Bytes the table of one call may take
+}
+
+// newMemoPlan returns the plan for memoizing prog within budget bytes, or
+// nil if no instruction can be remembered.
+func newMemoPlan(prog *Prog, budget int) *memoPlan {
+	if budget <= 0 {
+		budget = DefaultMemoBudget
+	}
+	insts := prog.Insts
+
+	// Count the ways into each instruction, and find the instructions whose
+	// outcome depends on the registers
+	indegree := make([]int, len(insts))
+	reads := make([]bool, len(insts))
+	indegree[prog.Start]++
+	for pc := range insts {
+		for _, next := range successors(insts, pc) {
+			indegree[next]++
+		}
+		reads[pc] = readsRegisters(&insts[pc])
+	}
+
+	// Spread the dependence back to every instruction that can reach one
+	for changed := true; changed; {
+		changed = false
+		for pc := range insts {
+			if reads[pc] {
+				continue
+			}
+			for _, next := range successors(insts, pc) {
+				if reads[next] {
+					reads[pc] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	plan := &memoPlan{slots: make([]int, len(insts)), budget: budget}
+	for pc := range insts {
+		plan.slots[pc] = -1
+		if indegree[pc] > 1 && !reads[pc] {
+			plan.slots[pc] = plan.n
+			plan.n++
+		}
+	}
+	if plan.n == 0 {
+		return nil
+	}
+	return plan
+}
+
+// successors returns the instructions that can run after the one at pc.
+func successors(insts []Inst, pc int) []int {
+	inst := &insts[pc]
+	var next []int
+	switch inst.Op {
+	case OpMatch:
+	case OpJmp, OpCountInc:
+		next = []int{inst.Out}
+	case OpSplit, OpCond, OpRepeat:
+		next = []int{inst.Out, inst.Out1}
+	case OpProgress:
+		next = []int{inst.Out, pc + 1}
+	default:
+		next = []int{pc + 1}
+	}
+	// Jumps past the end fail
+	for i := 0; i < len(next); i++ {
+		if next[i] >= len(insts) {
+			next = append(next[:i], next[i+1:]...)
+			i--
+		}
+	}
+	return next
+}
+
+// readsRegisters reports whether the outcome of inst depends on the
+// registers, directly or through its subprogram.
+func readsRegisters(inst *Inst) bool {
+	switch inst.Op {
+	case OpBackref, OpRestorePos, OpPopCap, OpTransferCap, OpCond,
+		OpRepeat, OpCountInc, OpScriptRun, OpProgress:
+		return true
+	case OpLookaround, OpAtomic, OpAbsent:
+		for i := range inst.Prog.Insts {
+			if readsRegisters(&inst.Prog.Insts[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// memoTable is the set of (pc, pos) states a search has visited. Rows are
+// positions from base on; a table too big for the budget covers as many
+// positions as fit, and states beyond them are not remembered.
+type memoTable struct {
+	bits             []uint64
+	base, rows       int
+	dirtyLo, dirtyHi int // Words of bits that may be set
+}
+
+// resetMemo empties the table for a search that starts at pos.
+func (vm *VM) resetMemo(plan *memoPlan, pos int) {
+	t := &vm.memo
+	if t.dirtyLo < t.dirtyHi {
+		clear(t.bits[t.dirtyLo:t.dirtyHi])
+	}
+	t.dirtyLo, t.dirtyHi = len(t.bits), 0
+	t.base = pos
+	t.rows = min(vm.input.Len()-pos+1, plan.budget*8/plan.n)
+	if words := (t.rows*plan.n + 63) / 64; words > len(t.bits) {
+		t.bits = make([]uint64, words)
+		t.dirtyLo = words
+	}
+	vm.memoPlan = plan
+}
+
+// visit records that the search has reached pc at pos, and reports whether
+// it is the first time.
+func (vm *VM) visit(pc, pos int) bool {
+	slot := vm.memoPlan.slots[pc]
+	row := pos - vm.memo.base
+	if slot < 0 || row < 0 || row >= vm.memo.rows {
+		return true
+	}
+	t := &vm.memo
+	i := row*vm.memoPlan.n + slot
+	word, bit := i/64, uint64(1)<<(i%64)
+	if t.bits[word]&bit != 0 {
+		return false
+	}
+	t.bits[word] |= bit
+	t.dirtyLo, t.dirtyHi = min(t.dirtyLo, word), max(t.dirtyHi, word+1)
+	return true
+}
@@ -0,0 +1,108 @@
+package gore
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMemoizeSameResults tests that memoization does not change what the
+// backtracker finds
+func TestMemoizeSameResults(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+	}{
+		{`a|ab`, "abab"},
+		{`(a|ab)(c|bcd)(d*)`, "abcd abcd"},
+		{`(a+)(b+)?`, "aaabbb aab a"},
+		{`(\w+)\s*=\s*(\w*)`, "key = value; k2=; x =y"},
+		{`(?m)^(\w+)$`, "one\ntwo\n\nthree"},
+		{`((a)|(b))+`, "abba"},
+		{`(\w)\1`, "abccdee"},
+		{`(a+)+\1b`, "aaaab aab"},
+		{`(\w+)\s\1(x+)+y`, "ab ab xxy cd cd xx"},
+		{`\b(\w+)(?=\s\1\b)`, "the the cat sat sat"},
+		{`(?<=\d)(\w+)(?!\d)`, "1abc 2de3 f"},
+		{`(?~ab)(\d)`, "xxab1 2"},
+		{`(a){2,12}`, "aaaaaaaaaaaaaaa"},
+		{`(?<o>\()|(?<-o>\))|[^()]`, "(a(b)c)"},
+		{`(a)?(?(1)b|c)`, "ab c b"},
+		{`(?i)(hello) (WORLD)`, "say Hello world and HELLO WORLD"},
+		// Loops whose iterations can match empty
+		{`(?:[^a]*?)*`, "c1"},
+		{`(?mi)(b?(?:c?|b*[^a]?.))*a*|[ab]*b+`, "bac1 "},
+		{`(a|b?)+c`, "abc bc"},
+		{`(?:(?=a)|b)*(\w)`, "bba"},
+	}
+	for _, tc := range tests {
+		plain := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack})
+		memo := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack, Memoize: true})
+		small := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack, Memoize: true, MemoBudget: 1})
+
+		want := plain.FindAllStringSubmatch(tc.input, -1)
+		if got := memo.FindAllStringSubmatch(tc.input, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s on %q: memoized = %q; want %q", tc.pattern, tc.input, got, want)
+		}
+		if got := small.FindAllStringSubmatch(tc.input, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s on %q: memoized within 1 byte = %q; want %q", tc.pattern, tc.input, got, want)
+		}
+	}
+}
+
+// TestMemoizeLinearTime tests patterns that are exponential for a plain
+// backtracker
+func TestMemoizeLinearTime(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    bool
+	}{
+		{`(a+)+b`, strings.Repeat("a", 5000), false},
+		{`(?=a)(a|aa)+$`, strings.Repeat("a", 5000) + "!", false},
+		{`^(\w+\s?)*(?<!x)$`, strings.Repeat("word ", 1000) + "!", false},
+		{`(x)\1(a+)+b`, "xx" + strings.Repeat("a", 5000), false},
+		{`(x+x+)+y(?=$)`, strings.Repeat("x", 2000) + "y", true},
+	}
+	for _, tc := range tests {
+		re := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack, Memoize: true})
+		start := time.Now()
+		got, err := re.MatchStringErr(tc.input)
+		if got != tc.want || err != nil {
+			t.Errorf("MatchStringErr(%q) = %v, %v; want %v, nil", tc.pattern, got, err, tc.want)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("%s: took %v", tc.pattern, elapsed)
+		}
+
+		// Without memoization the same search runs out of budget
+		plain := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack})
+		if _, err := plain.MatchStringErr(tc.input); !errors.Is(err, ErrMatchLimit) && tc.want == false {
+			t.Errorf("%s without memoization: err = %v; want ErrMatchLimit", tc.pattern, err)
+		}
+	}
+}
+
+// TestMemoPlan tests which instructions are memoized
+func TestMemoPlan(t *testing.T) {
+	tests := []struct {
+		pattern string
+		memo    bool
+	}{
+		{`(a+)+b`, true},
+		{`(a)\1`, false},
+		{`(a+)+\1`, false},
+		{`(a)\1(b+)+`, true},
+		{`(?=(a))(b+)+`, true},
+		{`(a)(?=\1)(b+)+`, true},
+		{`abc`, false},
+	}
+	for _, tc := range tests {
+		re := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack, Memoize: true})
+		if got := re.memoPlan != nil; got != tc.memo {
+			t.Errorf("%s: memoized = %v; want %v", tc.pattern, got, tc.memo)
+		}
+	}
+}
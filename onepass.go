@@ -0,0 +1,315 @@
+package gore
+
+import (
+	"slices"
+	"unicode"
+)
+
+// A one-pass program is an anchored regular program that never has to
+// choose: wherever it can go two ways, the runes each way accepts next are
+// disjoint. It can run as a single thread that follows the next rune,
+// setting the registers as it goes, with no backtracking and no thread
+// lists. Validation patterns such as ^\d{4}-\d{2}-\d{2}$ are usually
+// one-pass.
+//
+// The only choice left is between stopping at a match and reading on. If
+// the match comes first in priority it wins; otherwise the thread reads on
+// and keeps the match to fall back to, as a backtracker would.
+
+// onePassPathLimit is the most ways through empty transitions a one-pass
+// program may have from any point, which keeps the analysis cheap.
+const onePassPathLimit = 64
+
+// onePassFoldLimit is the most runes in a case-insensitive class whose case
+// folds the analysis works out; larger classes are taken to accept any rune.
+const onePassFoldLimit = 1024
+
+// onePassPath is one way through empty transitions to an instruction that
+// consumes input or matches.
+type onePassPath struct {
+	saves   []int // Registers set to the current position on the way
+	asserts []int // Assertions that must hold, as pcs
+	pc      int
+}
+
+// onePass is a program analyzed for the one-pass engine. next holds, for
+// the start and for every instruction after one that consumes input, the
+// paths from there in priority order.
+type onePass struct {
+	next [][]onePassPath
+}
+
+// compileOnePass returns prog analyzed for the one-pass engine, or nil if
+// it is not one-pass.
+func compileOnePass(prog *Prog) *onePass {
+	if !prog.isRegular() {
+		return nil
+	}
+	op := &onePass{next: make([][]onePassPath, len(prog.Insts)+1)}
+	entries := []int{prog.Start}
+	for pc, inst := range prog.Insts {
+		switch inst.Op {
+		case OpChar, OpCharClass, OpAny:
+			entries = append(entries, pc+1)
+		}
+	}
+	for _, pc := range entries {
+		paths, ok := onePassPaths(prog, pc)
+		if !ok || !onePassDisjoint(prog, paths) {
+			return nil
+		}
+		op.next[pc] = paths
+	}
+
+	// Every way from the start must be anchored at the beginning of the input
+	for _, path := range op.next[prog.Start] {
+		anchored := false
+		for _, pc := range path.asserts {
+			inst := &prog.Insts[pc]
+			if inst.Assert == AssertStringStart || inst.Assert == AssertStartText && !inst.Multiline {
+				anchored = true
+			}
+		}
+		if !anchored {
+			return nil
+		}
+	}
+	return op
+}
+
+// onePassPaths lists the paths from pc in priority order. It fails if there
+// are too many or if one goes round a loop without consuming input.
+func onePassPaths(prog *Prog, pc int) ([]onePassPath, bool) {
+	var paths []onePassPath
+	var saves, asserts []int
+	onStack := make([]bool, len(prog.Insts))
+
+	var walk func(pc int) bool
+	walk = func(pc int) bool {
+		if pc >= len(prog.Insts) {
+			return true // Fails at run time, so no path
+		}
+		if onStack[pc] {
+			return false
+		}
+		onStack[pc] = true
+		defer func() { onStack[pc] = false }()
+
+		inst := &prog.Insts[pc]
+		switch inst.Op {
+		case OpJmp:
+			return walk(inst.Out)
+		case OpSplit:
+			return walk(inst.Out) && walk(inst.Out1)
+		case OpSave:
+			saves = append(saves, inst.Idx)
+			defer func() { saves = saves[:len(saves)-1] }()
+			return walk(pc + 1)
+		case OpAssert:
+			asserts = append(asserts, pc)
+			defer func() { asserts = asserts[:len(asserts)-1] }()
+			return walk(pc + 1)
+		}
+		if len(paths) == onePassPathLimit {
+			return false
+		}
+		paths = append(paths, onePassPath{
+			saves:   slices.Clone(saves),
+			asserts: slices.Clone(asserts),
+			pc:      pc,
+		})
+		return true
+	}
+	return paths, walk(pc)
+}
+
+// onePassDisjoint reports whether no rune is accepted by two of the paths.
+func onePassDisjoint(prog *Prog, paths []onePassPath) bool {
+	var sets [][]RuneRange
+	for _, path := range paths {
+		if inst := &prog.Insts[path.pc]; inst.Op != OpMatch {
+			sets = append(sets, runeSet(inst))
+		}
+	}
+	for i := range sets {
+		for j := i + 1; j < len(sets); j++ {
+			if overlaps(sets[i], sets[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// runeSet returns the runes inst accepts as sorted, disjoint ranges. Large
+// case-insensitive classes are rounded up to every rune.
+func runeSet(inst *Inst) []RuneRange {
+	if inst.Op == OpCharClass && inst.FoldCase {
+		size := 0
+		for _, rng := range inst.Ranges {
+			size += int(rng.Hi-rng.Lo) + 1
+		}
+		if size > onePassFoldLimit {
+			return []RuneRange{{0, unicode.MaxRune}}
+		}
+	}
+	return inst.RuneSet()
+}
+
+// RuneSet returns the runes that inst, an OpChar, OpCharClass or OpAny,
+// accepts as sorted, disjoint ranges, with case folding and negation
+// applied.
+func (inst *Inst) RuneSet() []RuneRange {
+	switch inst.Op {
+	case OpChar:
+		set := []RuneRange{{inst.Val, inst.Val}}
+		if inst.FoldCase {
+			for f := unicode.SimpleFold(inst.Val); f != inst.Val; f = unicode.SimpleFold(f) {
+				set = append(set, RuneRange{f, f})
+			}
+		}
+		return normalizeRanges(set)
+	case OpAny:
+		return []RuneRange{{0, '\n' - 1}, {'\n' + 1, unicode.MaxRune}}
+	}
+
+	set := slices.Clone(inst.Ranges)
+	if inst.FoldCase {
+		for _, rng := range inst.Ranges {
+			for r := rng.Lo; r <= rng.Hi; r++ {
+				for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+					set = append(set, RuneRange{f, f})
+				}
+			}
+		}
+	}
+	set = normalizeRanges(set)
+	if !inst.Negated {
+		return set
+	}
+	var negated []RuneRange
+	next := rune(0)
+	for _, rng := range set {
+		if rng.Lo > next {
+			negated = append(negated, RuneRange{next, rng.Lo - 1})
+		}
+		next = rng.Hi + 1
+	}
+	if next <= unicode.MaxRune {
+		negated = append(negated, RuneRange{next, unicode.MaxRune})
+	}
+	return negated
+}
+
+// normalizeRanges sorts ranges and merges the ones that touch.
+func normalizeRanges(ranges []RuneRange) []RuneRange {
+	slices.SortFunc(ranges, func(a, b RuneRange) int { return int(a.Lo - b.Lo) })
+	var merged []RuneRange
+	for _, rng := range ranges {
+		if n := len(merged); n > 0 && rng.Lo <= merged[n-1].Hi+1 {
+			merged[n-1].Hi = max(merged[n-1].Hi, rng.Hi)
+			continue
+		}
+		merged = append(merged, rng)
+	}
+	return merged
+}
+
+// overlaps reports whether two sorted, disjoint range lists share a rune.
+func overlaps(a, b []RuneRange) bool {
+	for len(a) > 0 && len(b) > 0 {
+		switch {
+		case a[0].Hi < b[0].Lo:
+			a = a[1:]
+		case b[0].Hi < a[0].Lo:
+			b = b[1:]
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// findOnePass is find for one-pass programs. The program is anchored, so
+// only a match at pos is possible.
+func (re *Regexp) findOnePass(vm *VM, pos int) ([]int, error) {
+	prog, input := vm.prog, vm.input
+	n := prog.NumRegs
+	if cap(vm.caps) < n {
+		vm.caps = make([]int, n)
+	}
+	caps := vm.caps[:n]
+	for i := range caps {
+		caps[i] = -1
+	}
+	matched := false
+	interruptible := vm.ctx != nil || !vm.deadline.IsZero()
+
+	paths := re.onePass.next[prog.Start]
+	for step := 0; ; step++ {
+		if interruptible && step&(interruptInterval-1) == 0 && vm.checkInterrupt() {
+			return nil, vm.err
+		}
+
+		r, w := input.Step(pos)
+		var next *onePassPath
+		for i := range paths {
+			path := &paths[i]
+			if len(path.asserts) > 0 && !vm.onePassAsserts(path, pos) {
+				continue
+			}
+			inst := &prog.Insts[path.pc]
+			if inst.Op == OpMatch {
+				if next == nil {
+					// Nothing before it reads on, so the match wins
+					setSaves(caps, path, pos)
+					vm.caps = caps
+					return caps, nil
+				}
+				// Keep the first match after the way on to fall back to
+				if cap(vm.alt) < n {
+					vm.alt = make([]int, n)
+				}
+				vm.alt = vm.alt[:n]
+				copy(vm.alt, caps)
+				setSaves(vm.alt, path, pos)
+				matched = true
+				break
+			}
+			if next == nil && w > 0 && instAccepts(inst, r) {
+				next = path
+			}
+		}
+		if next == nil {
+			break
+		}
+		setSaves(caps, next, pos)
+		pos += w
+		paths = re.onePass.next[next.pc+1]
+	}
+
+	if !matched {
+		return nil, nil
+	}
+	copy(caps, vm.alt)
+	vm.caps = caps
+	return caps, nil
+}
+
+// onePassAsserts reports whether the assertions on path hold at pos.
+func (vm *VM) onePassAsserts(path *onePassPath, pos int) bool {
+	for _, pc := range path.asserts {
+		inst := &vm.prog.Insts[pc]
+		if !vm.checkAssertion(inst.Assert, pos, inst.Multiline, inst.Unicode) {
+			return false
+		}
+	}
+	return true
+}
+
+// setSaves sets the registers path saves to pos.
+func setSaves(caps []int, path *onePassPath, pos int) {
+	for _, reg := range path.saves {
+		caps[reg] = pos
+	}
+}
@@ -0,0 +1,104 @@
+package gore
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"unicode"
+)
+
+// TestOnePassMatchesBacktrack tests that the one-pass engine finds the same
+// matches and submatches as the backtracker
+func TestOnePassMatchesBacktrack(t *testing.T) {
+	tests := []struct {
+		pattern string
+		inputs  []string
+	}{
+		{`^(\d{4})-(\d{2})-(\d{2})$`, []string{"2024-06-30", "2024-6-30", "2024-06-30\n", "x2024-06-30"}},
+		{`^([a-z]+)=([^;]*)$`, []string{"key=value", "key=", "=v", "k=v;", "k=a=b"}},
+		{`^a(bc)?`, []string{"abc", "abx", "a", "ab", "b"}},
+		{`^a(bc)??`, []string{"abc", "a"}},
+		{`^(a*?)b`, []string{"aab", "b", "aa"}},
+		{`^(\w+)@(\w+)\.com\b`, []string{"bob@example.com", "bob@example.comx", "bob@example.com."}},
+		{`\A(?i)(hello) (world)\z`, []string{"HeLLo WORLD", "hello world!", "hello  world"}},
+		{`^(x)?(y)?z`, []string{"z", "xz", "yz", "xyz", "xyyz"}},
+		{`^(\d+)(?:\.(\d+))?$`, []string{"1.2", "12", "1.", ".2"}},
+		{`^é+(ü)?$`, []string{"ééü", "é", "ü"}},
+		{`^$`, []string{"", "a"}},
+	}
+	for _, tc := range tests {
+		re := MustCompileWithOptions(tc.pattern, Options{NoStdlib: true})
+		if re.Engine() != EngineOnePass {
+			t.Errorf("%s: runs on %v; want onepass", tc.pattern, re.Engine())
+			continue
+		}
+		bt := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack})
+		for _, input := range tc.inputs {
+			if got, want := re.FindAllStringSubmatch(input, -1), bt.FindAllStringSubmatch(input, -1); !reflect.DeepEqual(got, want) {
+				t.Errorf("FindAllStringSubmatch(%q, %q) = %q; backtracker gives %q", tc.pattern, input, got, want)
+			}
+			if got, want := re.MatchString(input), bt.MatchString(input); got != want {
+				t.Errorf("MatchString(%q, %q) = %v; backtracker gives %v", tc.pattern, input, got, want)
+			}
+		}
+	}
+}
+
+// TestOnePassDetection tests which patterns are one-pass
+func TestOnePassDetection(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{`^[a-z]+=[^;]*$`, true},
+		{`^(?i)k+`, true},
+		{`^(a|b)+c`, true},
+		{`[a-z]+`, false},       // Not anchored
+		{`(?m)^[a-z]+`, false},  // Anchored at every line
+		{`^a|b`, false},         // Not anchored on every branch
+		{`^(a|ab)`, false},      // Both branches read a
+		{`^\w+\d`, false},       // The loop and the digit overlap
+		{`^(?:(?i)k|K)`, false}, // K folds to the Kelvin sign
+		{`^(a*)*`, false},       // Loops without reading
+		{`^(a)\1`, false},       // Not regular
+	}
+	for _, tc := range tests {
+		if got := compileOnePass(MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack}).prog) != nil; got != tc.want {
+			t.Errorf("%s: one-pass = %v; want %v", tc.pattern, got, tc.want)
+		}
+	}
+
+	var err *Error
+	if _, compileErr := CompileWithOptions(`^(a|ab)`, Options{Engine: EngineOnePass}); !errors.As(compileErr, &err) || err.Code != ErrUnsupported {
+		t.Errorf("forced one-pass engine: err = %v; want ErrUnsupported", compileErr)
+	}
+	if got := MustCompileWithOptions(`^\d+$`, Options{Engine: EnginePike}).Engine(); got != EnginePike {
+		t.Errorf("forced Pike VM: Engine() = %v", got)
+	}
+}
+
+// TestInstRuneSet tests the runes an instruction accepts
+func TestInstRuneSet(t *testing.T) {
+	tests := []struct {
+		inst Inst
+		want []RuneRange
+	}{
+		{Inst{Op: OpChar, Val: 'k', FoldCase: true}, []RuneRange{{'K', 'K'}, {'k', 'k'}, {'K', 'K'}}},
+		{Inst{Op: OpCharClass, Ranges: []RuneRange{{'d', 'f'}, {'a', 'c'}}}, []RuneRange{{'a', 'f'}}},
+		{Inst{Op: OpCharClass, Ranges: []RuneRange{{'b', 'y'}}, Negated: true}, []RuneRange{{0, 'a'}, {'z', unicode.MaxRune}}},
+		{Inst{Op: OpAny}, []RuneRange{{0, '\n' - 1}, {'\n' + 1, unicode.MaxRune}}},
+	}
+	for _, tc := range tests {
+		if got := tc.inst.RuneSet(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: RuneSet() = %v; want %v", &tc.inst, got, tc.want)
+		}
+	}
+
+	// The one-pass analysis rounds large case-insensitive classes up to
+	// every rune, but RuneSet is exact
+	inst := Inst{Op: OpCharClass, Ranges: []RuneRange{{'a', 0x2000}}, FoldCase: true}
+	if got := inst.RuneSet(); slices.Contains(got, RuneRange{0, unicode.MaxRune}) || !slices.Contains(got, RuneRange{'A', 'Z'}) {
+		t.Errorf("%v: RuneSet() = %v", &inst, got)
+	}
+}
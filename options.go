@@ -0,0 +1,260 @@
+package gore
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Options configure how a pattern is compiled. The zero value compiles a
+// pattern the same way as Compile. The modes match the inline flags, which
+// can still turn them off again inside the pattern.
+type Options struct {
+	CaseInsensitive bool // (?i)
+	Multiline       bool // (?m): ^ and $ match at line boundaries
+	DotAll          bool // (?s): . matches \n
+	Extended        bool // (?x): whitespace and # comments are ignored
+	Ungreedy        bool // (?U): quantifiers are lazy unless followed by ?
+	Unicode         bool // (*UCP): \d, \w, \s and \b use Unicode properties
+	DupNames        bool // (?J): groups may share a name
+	NoAutoCapture   bool // (?n): only named groups capture
+
+	// Anchored only allows matches that start at the beginning of the input,
+	// as if the pattern began with \A.
+	Anchored bool
+
+	// Literal matches the pattern text as a plain string, with no
+	// metacharacters. Only CaseInsensitive, Anchored and Limits apply.
+	Literal bool
+
+	// Lenient treats unknown escapes of letters and digits as literals, like
+	// the Lenient flag.
+	Lenient bool
+
+	Limits Limits
+
+	// Engine picks the engine that runs the pattern. Compile fails with
+	// ErrUnsupported if the pattern needs features the engine lacks.
+	Engine Engine
+
+	// MatchTimeout stops any call that runs for longer, like .NET's
+	// Regex.MatchTimeout. The error variants then return an error wrapping
+	// ErrMatchTimeout. Zero means no timeout.
+	MatchTimeout time.Duration
+
+	// Memoize makes the backtracking engine remember the states it has
+	// already tried, so that a search takes time linear in the input even
+	// on patterns like (a+)+b that need backreferences or lookaround. Parts
+	// of the pattern that lead to a backreference or another construct that
+	// depends on what was captured are not memoized. The table of a call
+	// takes up to MemoBudget bytes, DefaultMemoBudget if zero; on longer
+	// inputs only the positions that fit are remembered.
+	Memoize    bool
+	MemoBudget int
+
+	// NoStdlib keeps Compile from handing the pattern to the standard
+	// library's regexp, so that it always runs on gore's own engines.
+	NoStdlib bool
+}
+
+// Limits bound the work the backtracking engine may do looking for a match
+// at each start position of a search. A search that runs out fails
+// with an error wrapping ErrMatchLimit. The Pike VM is linear and ignores
+// them. (*LIMIT_MATCH=n) and (*LIMIT_DEPTH=n) in the pattern can
+// lower the limits but not raise them.
+type Limits struct {
+	// MatchLimit caps the instructions the VM runs. Zero means
+	// DefaultMatchLimit and a negative value means no limit.
+	MatchLimit int
+
+	DepthLimit     int // Nested backtracking points the VM may hold, 0 for no limit
+	BacktrackLimit int // Times the VM may backtrack, 0 for no limit
+
+	// StackLimit caps the memory of the backtrack stack, in bytes. Zero
+	// means DefaultStackLimit and a negative value means no limit.
+	StackLimit int
+}
+
+// DefaultMatchLimit is the instruction budget of a search when
+// Limits.MatchLimit is zero.
+const DefaultMatchLimit = 10000000
+
+// DefaultStackLimit is the memory the backtrack stack of a search may use
+// when Limits.StackLimit is zero.
+const DefaultStackLimit = 256 << 20
+
+// ErrMatchLimit is wrapped by the error returned when a search exceeds one
+// of its Limits.
+var ErrMatchLimit = errors.New("gore: match limit exceeded")
+
+// ErrMatchTimeout is wrapped by the error returned when a call runs for longer
+// than Options.MatchTimeout.
+var ErrMatchTimeout = errors.New("gore: match timeout")
+
+// resolve returns the limits the VM enforces, where zero means no limit.
+func (l Limits) resolve() Limits {
+	switch {
+	case l.MatchLimit == 0:
+		l.MatchLimit = DefaultMatchLimit
+	case l.MatchLimit < 0:
+		l.MatchLimit = 0
+	}
+	switch {
+	case l.StackLimit == 0:
+		l.StackLimit = DefaultStackLimit
+	case l.StackLimit < 0:
+		l.StackLimit = 0
+	}
+	return l
+}
+
+// CompileWithOptions is like Compile but configures the pattern with opts
+// instead of, or as well as, inline flags.
+func CompileWithOptions(expr string, opts Options) (*Regexp, error) {
+	parser := NewParser(expr)
+	parser.flags = parseFlags{
+		caseInsensitive: opts.CaseInsensitive,
+		multiline:       opts.Multiline,
+		dotall:          opts.DotAll,
+		dupNames:        opts.DupNames,
+		noAutoCapture:   opts.NoAutoCapture,
+		ucp:             opts.Unicode,
+		extended:        opts.Extended,
+		ungreedy:        opts.Ungreedy,
+	}
+	parser.lenient = opts.Lenient
+
+	var node Node
+	if opts.Literal {
+		node = &Literal{Runes: []rune(expr), FoldCase: opts.CaseInsensitive}
+	} else {
+		var err error
+		if node, err = parser.Parse(); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Anchored {
+		node = &Concat{Nodes: []Node{&Assertion{Kind: AssertStringStart}, node}}
+	}
+
+	compiler := NewCompiler()
+	prog, err := compiler.Compile(node, parser.captures)
+	if err != nil {
+		if e, ok := err.(*Error); ok {
+			e.Pattern = expr
+		}
+		return nil, err
+	}
+	engine := opts.Engine
+	if engine == EngineStdlib {
+		engine = EngineAuto
+	}
+	var onePass *onePass
+	if engine == EngineAuto || engine == EngineOnePass {
+		onePass = compileOnePass(prog)
+	}
+	switch {
+	case engine == EngineAuto && onePass != nil:
+		engine = EngineOnePass
+	case engine == EngineAuto && prog.isRegular():
+		engine = EnginePike
+	case engine == EngineAuto:
+		engine = EngineBacktrack
+	case engine == EnginePike && !prog.isRegular():
+		return nil, &Error{
+			Code:    ErrUnsupported,
+			Pattern: expr,
+			Msg:     "pattern needs the backtracking engine",
+		}
+	case engine == EngineOnePass && onePass == nil:
+		return nil, &Error{
+			Code:    ErrUnsupported,
+			Pattern: expr,
+			Msg:     "pattern is not one-pass",
+		}
+	}
+
+	opts.Limits.MatchLimit = minLimit(opts.Limits.MatchLimit, parser.start.matchLimit)
+	opts.Limits.DepthLimit = minLimit(opts.Limits.DepthLimit, parser.start.depthLimit)
+	opts.Unicode = opts.Unicode || parser.start.ucp
+	if parser.start.noStartOpt {
+		prog.Prefix, prog.prefilter = "", nil
+	}
+
+	// Build subexp names from parser
+	names := make([]string, parser.captures+1)
+	for name, idxs := range parser.names {
+		for _, idx := range idxs {
+			if idx < len(names) {
+				names[idx] = name
+			}
+		}
+	}
+
+	re := &Regexp{
+		expr:        expr,
+		prog:        prog,
+		subexpNames: names,
+		opts:        opts,
+		engine:      engine,
+		onePass:     onePass,
+	}
+	if engine == EnginePike {
+		re.pikePool = newPikePool(prog)
+		if dfaCapable(prog) {
+			reverse, err := NewCompiler().compileReverse(node, parser.captures)
+			if err != nil {
+				return nil, err
+			}
+			re.dfaPool = newDFAPool(prog, reverse)
+		}
+	}
+	if opts.Engine == EngineStdlib || opts.Engine == EngineAuto && !opts.NoStdlib {
+		re.std = compileStdlib(node, prog)
+		if re.std == nil && opts.Engine == EngineStdlib {
+			return nil, &Error{
+				Code:    ErrUnsupported,
+				Pattern: expr,
+				Msg:     "pattern needs features the standard library lacks",
+			}
+		}
+	}
+	if engine == EngineBacktrack && opts.Memoize {
+		re.memoPlan = newMemoPlan(prog, opts.MemoBudget)
+	}
+	return re, nil
+}
+
+// MustCompileWithOptions is like CompileWithOptions but panics if the
+// expression cannot be parsed.
+func MustCompileWithOptions(expr string, opts Options) *Regexp {
+	re, err := CompileWithOptions(expr, opts)
+	if err != nil {
+		panic(fmt.Sprintf("gore: CompileWithOptions(%q): %v", expr, err))
+	}
+	return re
+}
+
+// Options returns the options re was compiled with. Limits and Unicode also
+// reflect the (*...) items at the start of the pattern.
+func (re *Regexp) Options() Options {
+	return re.opts
+}
+
+// WithLimits returns a copy of re that enforces limits instead of the ones it
+// was compiled with. The copy shares the compiled program, so it is cheap to
+// make one for a single call.
+func (re *Regexp) WithLimits(limits Limits) *Regexp {
+	cp := *re
+	cp.opts.Limits = limits
+	return &cp
+}
+
+// minLimit returns the smaller of two limits, where a value of 0 or less
+// means none was given.
+func minLimit(a, b int) int {
+	if b > 0 && (a <= 0 || b < a) {
+		return b
+	}
+	return a
+}
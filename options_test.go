@@ -0,0 +1,287 @@
+package gore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCompileWithOptions tests setting modes through Options
+func TestCompileWithOptions(t *testing.T) {
+	tests := []struct {
+		pattern string
+		opts    Options
+		input   string
+		want    string
+	}{
+		{`hello`, Options{CaseInsensitive: true}, "say HELLO", "HELLO"},
+		{`(?-i)hello`, Options{CaseInsensitive: true}, "say HELLO", ""},
+		{`^b$`, Options{Multiline: true}, "a\nb\nc", "b"},
+		{`a.c`, Options{DotAll: true}, "a\nc", "a\nc"},
+		{`a.c`, Options{}, "a\nc", ""},
+
+		// Extended mode ignores whitespace and comments outside classes
+		{"\\d{4} - \\d{2}  # year and month\n", Options{Extended: true}, "on 2024-05", "2024-05"},
+		{`a [ ] b`, Options{Extended: true}, "a b", "a b"},
+		{`a\ b`, Options{Extended: true}, "a b", "a b"},
+		{`(?x) a + b`, Options{}, "aaab", "aaab"},
+		{`(?x: a b ) c`, Options{}, "ab c", "ab c"},
+
+		// Ungreedy mode swaps the meaning of ? after a quantifier
+		{`a+`, Options{Ungreedy: true}, "aaa", "a"},
+		{`a+?`, Options{Ungreedy: true}, "aaa", "aaa"},
+		{`a{1,3}`, Options{Ungreedy: true}, "aaa", "a"},
+		{`(?U)<.+>`, Options{}, "<a><b>", "<a>"},
+
+		{`\w+`, Options{Unicode: true}, "¡olé!", "olé"},
+
+		// Anchored matches only at the start of the input
+		{`b`, Options{Anchored: true}, "ab", ""},
+		{`a|b`, Options{Anchored: true}, "ba", "b"},
+
+		// Literal takes the pattern as plain text
+		{`a.b(c)`, Options{Literal: true}, "xa.b(c)", "a.b(c)"},
+		{`a.b`, Options{Literal: true}, "axb", ""},
+		{`A.B`, Options{Literal: true, CaseInsensitive: true}, "a.b", "a.b"},
+		{`(x`, Options{Literal: true}, "(x", "(x"},
+	}
+	for _, tc := range tests {
+		re, err := CompileWithOptions(tc.pattern, tc.opts)
+		if err != nil {
+			t.Errorf("CompileWithOptions(%q, %+v) error: %v", tc.pattern, tc.opts, err)
+			continue
+		}
+		if got := re.FindString(tc.input); got != tc.want {
+			t.Errorf("CompileWithOptions(%q, %+v).FindString(%q) = %q; want %q", tc.pattern, tc.opts, tc.input, got, tc.want)
+		}
+	}
+
+	// Captures in literal mode
+	if got := MustCompileWithOptions(`(a)`, Options{Literal: true}).NumSubexp(); got != 0 {
+		t.Errorf("literal NumSubexp() = %d; want 0", got)
+	}
+}
+
+// TestOptionsReadBack tests Regexp.Options
+func TestOptionsReadBack(t *testing.T) {
+	opts := Options{CaseInsensitive: true, Extended: true, Limits: Limits{MatchLimit: 5000}}
+	if got := MustCompileWithOptions(`a b`, opts).Options(); !reflect.DeepEqual(got, opts) {
+		t.Errorf("Options() = %+v; want %+v", got, opts)
+	}
+
+	// Items in the pattern can lower the limits and turn on Unicode
+	got := MustCompileWithOptions(`(*LIMIT_MATCH=100)(*LIMIT_DEPTH=9)(*UCP)a`, opts).Options()
+	want := opts
+	want.Unicode = true
+	want.Limits = Limits{MatchLimit: 100, DepthLimit: 9}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Options() = %+v; want %+v", got, want)
+	}
+	if got := MustCompileWithOptions(`(*LIMIT_MATCH=9000)a`, opts).Options().Limits.MatchLimit; got != 5000 {
+		t.Errorf("pattern raised MatchLimit to %d; want 5000", got)
+	}
+
+	if got := MustCompile(`a`).Options(); got != (Options{}) {
+		t.Errorf("Compile Options() = %+v; want zero", got)
+	}
+}
+
+// TestOptionLimits tests limits given as options
+func TestOptionLimits(t *testing.T) {
+	re := MustCompileWithOptions(`^(a|b)*c`, Options{Limits: Limits{DepthLimit: 10}, Engine: EngineBacktrack})
+	if re.MatchString("ababababababababc") {
+		t.Error("match should stop at the depth limit")
+	}
+	if !re.MatchString("abc") {
+		t.Error("short match should stay within the depth limit")
+	}
+}
+
+// TestMatchLimitErrors tests that exceeded limits are reported as errors
+func TestMatchLimitErrors(t *testing.T) {
+	pathological := MustCompileWithOptions(`(a+)+b`, Options{Engine: EngineBacktrack})
+	input := strings.Repeat("a", 30)
+
+	// The default budget stops an exponential search
+	if _, err := pathological.MatchStringErr(input); !errors.Is(err, ErrMatchLimit) {
+		t.Errorf("default limits: err = %v; want ErrMatchLimit", err)
+	}
+	if pathological.MatchString(input) {
+		t.Error("MatchString should report false when the limit is hit")
+	}
+
+	limits := []Limits{
+		{MatchLimit: 1000},
+		{BacktrackLimit: 100},
+		{DepthLimit: 10},
+	}
+	for _, l := range limits {
+		re := pathological.WithLimits(l)
+		if _, err := re.MatchStringErr(input); !errors.Is(err, ErrMatchLimit) {
+			t.Errorf("%+v: MatchStringErr err = %v; want ErrMatchLimit", l, err)
+		}
+		if _, err := re.FindStringSubmatchErr(input); !errors.Is(err, ErrMatchLimit) {
+			t.Errorf("%+v: FindStringSubmatchErr err = %v; want ErrMatchLimit", l, err)
+		}
+		if _, err := re.MatchReader(strings.NewReader(input)); !errors.Is(err, ErrMatchLimit) {
+			t.Errorf("%+v: MatchReader err = %v; want ErrMatchLimit", l, err)
+		}
+
+		// Small inputs stay within the same limits
+		if m, err := re.FindStringErr("xaab"); m != "aab" || err != nil {
+			t.Errorf("%+v: FindStringErr = %q, %v; want \"aab\", nil", l, m, err)
+		}
+	}
+
+	// The original keeps its own limits
+	if _, err := pathological.WithLimits(Limits{MatchLimit: -1}).MatchStringErr(strings.Repeat("a", 16)); err != nil {
+		t.Errorf("unlimited search err = %v", err)
+	}
+
+	// Each match of FindAll gets a fresh budget; matches before the failure
+	// are returned
+	re := MustCompileWithOptions(`\w+ |(a+)+b`, Options{Limits: Limits{MatchLimit: 5000}, Engine: EngineBacktrack})
+	matches, err := re.FindAllStringIndexErr("one two three "+input, -1)
+	if !errors.Is(err, ErrMatchLimit) {
+		t.Errorf("FindAllStringIndexErr err = %v; want ErrMatchLimit", err)
+	}
+	if want := [][]int{{0, 4}, {4, 8}, {8, 14}}; !reflect.DeepEqual(matches, want) {
+		t.Errorf("FindAllStringIndexErr = %v; want %v", matches, want)
+	}
+	got, err := re.ReplaceAllStringErr("one two "+input, "_")
+	if !errors.Is(err, ErrMatchLimit) || got != "__"+input {
+		t.Errorf("ReplaceAllStringErr = %q, %v; want %q, ErrMatchLimit", got, err, "__"+input)
+	}
+}
+
+// TestMatchLimitLargeInput tests that the budget is per start position, so
+// that ordinary patterns do not run out of it on long inputs
+func TestMatchLimitLargeInput(t *testing.T) {
+	text := strings.Repeat("lorem ipsum dolor sit amet ", 80000) // About 2 MB
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{`(\w+) \1`, "ab ab"},
+		{`\w+(?=\d)`, "end"},
+	}
+	for _, tc := range tests {
+		re := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack})
+		got, err := re.FindStringErr(text + "ab ab end1")
+		if got != tc.want || err != nil {
+			t.Errorf("%s: FindStringErr = %q, %v; want %q, nil", tc.pattern, got, err, tc.want)
+		}
+	}
+}
+
+// TestMatchContext tests stopping a search through its context
+func TestMatchContext(t *testing.T) {
+	pathological := MustCompileWithOptions(`(a+)+b`, Options{Engine: EngineBacktrack}).WithLimits(Limits{MatchLimit: -1})
+	input := strings.Repeat("a", 40)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := pathological.MatchStringContext(canceled, input); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled: err = %v; want context.Canceled", err)
+	}
+	if _, err := pathological.MatchContext(canceled, []byte(input)); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled: MatchContext err = %v; want context.Canceled", err)
+	}
+
+	// A deadline stops a search that is already running
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := pathological.FindStringSubmatchContext(ctx, input); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("deadline: err = %v; want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("deadline: search ran for %v", elapsed)
+	}
+
+	// A live context does not change the results
+	re := MustCompile(`(\w)(\d)`)
+	ctx = context.Background()
+	if m, err := re.FindStringContext(ctx, "x a1 b2"); m != "a1" || err != nil {
+		t.Errorf("FindStringContext = %q, %v; want \"a1\", nil", m, err)
+	}
+	if m, err := re.FindStringSubmatchContext(ctx, "x a1"); !reflect.DeepEqual(m, []string{"a1", "a", "1"}) || err != nil {
+		t.Errorf("FindStringSubmatchContext = %q, %v", m, err)
+	}
+	if m, err := re.FindAllStringIndexContext(ctx, "a1 b2", -1); !reflect.DeepEqual(m, [][]int{{0, 2}, {3, 5}}) || err != nil {
+		t.Errorf("FindAllStringIndexContext = %v, %v", m, err)
+	}
+	if got, err := re.ReplaceAllStringContext(ctx, "a1 b2", "$2$1"); got != "1a 2b" || err != nil {
+		t.Errorf("ReplaceAllStringContext = %q, %v; want \"1a 2b\", nil", got, err)
+	}
+
+	// Matches found before cancellation are kept
+	matches, err := MustCompileWithOptions(`\w+ |(a+)+b`, Options{Engine: EngineBacktrack}).WithLimits(Limits{MatchLimit: -1}).
+		FindAllStringSubmatchContext(timeoutContext(t, 20*time.Millisecond), "one two "+input, -1)
+	if !errors.Is(err, context.DeadlineExceeded) || len(matches) != 2 {
+		t.Errorf("FindAllStringSubmatchContext = %q, %v; want 2 matches, context.DeadlineExceeded", matches, err)
+	}
+}
+
+// timeoutContext returns a context that times out after d and is canceled when the
+// test ends.
+func timeoutContext(t *testing.T, d time.Duration) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+// TestMatchTimeout tests the per-Regexp default timeout
+func TestMatchTimeout(t *testing.T) {
+	re := MustCompileWithOptions(`(a+)+b`, Options{
+		Engine:       EngineBacktrack,
+		Limits:       Limits{MatchLimit: -1},
+		MatchTimeout: 20 * time.Millisecond,
+	})
+	input := strings.Repeat("a", 40)
+
+	if _, err := re.MatchStringErr(input); !errors.Is(err, ErrMatchTimeout) {
+		t.Errorf("MatchStringErr err = %v; want ErrMatchTimeout", err)
+	}
+	if re.MatchString(input) {
+		t.Error("MatchString should report false when the timeout passes")
+	}
+	if got, err := re.ReplaceAllStringErr("aab "+input, "_"); !errors.Is(err, ErrMatchTimeout) || got != "_ "+input {
+		t.Errorf("ReplaceAllStringErr = %q, %v; want ErrMatchTimeout", got, err)
+	}
+	if got := re.Options().MatchTimeout; got != 20*time.Millisecond {
+		t.Errorf("Options().MatchTimeout = %v", got)
+	}
+
+	// Quick searches finish well within the timeout
+	if m, err := re.FindStringErr("xaab"); m != "aab" || err != nil {
+		t.Errorf("FindStringErr = %q, %v; want \"aab\", nil", m, err)
+	}
+}
+
+// TestBacktrackStack tests that long inputs do not grow the Go stack and
+// that the backtrack stack stays within its limit
+func TestBacktrackStack(t *testing.T) {
+	input := strings.Repeat("ab", 1<<20)
+	unlimited := Limits{MatchLimit: -1}
+
+	tests := []string{`^(a|b)*$`, `^.*$`, `^(?:ab)*?$`, `^[ab]*b$`}
+	for _, pattern := range tests {
+		re := MustCompileWithOptions(pattern, Options{Engine: EngineBacktrack}).WithLimits(unlimited)
+		if m, err := re.MatchStringErr(input); !m || err != nil {
+			t.Errorf("%s: MatchStringErr = %v, %v; want true, nil", pattern, m, err)
+		}
+	}
+
+	// Running out of stack is an error, not a crash
+	re := MustCompileWithOptions(`^(a|b)*$`, Options{Engine: EngineBacktrack}).WithLimits(Limits{MatchLimit: -1, StackLimit: 1 << 16})
+	if _, err := re.MatchStringErr(input); !errors.Is(err, ErrMatchLimit) {
+		t.Errorf("StackLimit: err = %v; want ErrMatchLimit", err)
+	}
+	if m, err := re.MatchStringErr("abab"); !m || err != nil {
+		t.Errorf("StackLimit: short input = %v, %v; want true, nil", m, err)
+	}
+}
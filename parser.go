@@ -1,9 +1,10 @@
 package gore
 
 import (
-	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -13,20 +14,47 @@ type Parser struct {
 	pos   int
 	// State for capturing groups
 	captures int
-	names    map[string]int
+	names    map[string][]int // Groups of each name, more than one under (?J)
+	stacked  map[int]bool     // Groups that keep a stack of their captures
+	pops     []groupRef       // Groups popped by balancing groups, checked once all are known
 	flags    parseFlags
+	start    startOptions // Set by (*...) items at the start of the pattern
+	groups   []int        // Offsets of the groups being parsed, innermost last
+	lenient  bool         // Unknown escapes are literals instead of errors
+}
+
+// startOptions holds the settings of the PCRE2 option items that may open a
+// pattern, such as (*LIMIT_MATCH=1000) or (*UCP).
+type startOptions struct {
+	matchLimit int  // (*LIMIT_MATCH=n), 0 if unset
+	depthLimit int  // (*LIMIT_DEPTH=n), 0 if unset
+	noStartOpt bool // (*NO_START_OPT): no prefix search
+	ucp        bool // (*UCP)
+}
+
+// groupRef is a group reference, found at offset, by number or by name.
+type groupRef struct {
+	index  int
+	name   string // Empty for references by number
+	offset int
 }
 
 type parseFlags struct {
 	caseInsensitive bool
 	multiline       bool
 	dotall          bool // for future (?s) implementation
+	dupNames        bool // (?J): groups may share a name
+	noAutoCapture   bool // (?n): only named groups capture
+	ucp             bool // (*UCP): \d, \w, \s and \b use Unicode properties
+	extended        bool // (?x): whitespace and # comments are ignored
+	ungreedy        bool // (?U): quantifiers are lazy unless followed by ?
 }
 
 func NewParser(input string) *Parser {
 	return &Parser{
-		input: input,
-		names: make(map[string]int),
+		input:   input,
+		names:   make(map[string][]int),
+		stacked: make(map[int]bool),
 	}
 }
 
@@ -41,16 +69,130 @@ func isIdentRune(r rune) bool {
 }
 
 func (p *Parser) Parse() (Node, error) {
+	if err := p.parseStartItems(); err != nil {
+		return nil, err
+	}
 	node, err := p.parseExpr()
 	if err != nil {
 		return nil, err
 	}
 	if p.pos < len(p.input) {
-		return nil, fmt.Errorf("unexpected character at %d: %q", p.pos, p.peek())
+		if p.peek() == ')' {
+			return nil, p.errorf(ErrUnexpectedParen, p.pos, "unmatched closing parenthesis")
+		}
+		return nil, p.errorf(ErrUnexpectedChar, p.pos, "unexpected character %q", p.peek())
+	}
+
+	// A balancing group may pop a group that comes later, so the numbers
+	// are checked once every group is known. A name that (?J) gives to
+	// several groups has one stack per group, which a pop cannot balance.
+	for _, ref := range p.pops {
+		if ref.index > p.captures {
+			return nil, p.errorf(ErrInvalidGroupRef, ref.offset, "reference to undefined group %d", ref.index)
+		}
+		if ref.name != "" && len(p.names[ref.name]) > 1 {
+			return nil, p.errorf(ErrUnsupported, ref.offset, "balancing groups cannot pop %q, which names more than one group", ref.name)
+		}
+	}
+
+	// Groups popped by balancing groups keep a stack of their captures
+	if len(p.stacked) > 0 {
+		walkNode(node, func(n Node) {
+			if c, ok := n.(*Capture); ok && p.stacked[c.Index] {
+				c.Stacked = true
+			}
+		})
 	}
 	return node, nil
 }
 
+// parseStartItems parses the option items at the start of the pattern:
+// (*LIMIT_MATCH=n), (*LIMIT_DEPTH=n), (*NO_START_OPT), (*UTF) and (*UCP).
+// Items are upper case, which tells them apart from (*pla:...) and the
+// other alphabetic groups.
+func (p *Parser) parseStartItems() error {
+	for strings.HasPrefix(p.input[p.pos:], "(*") {
+		end := p.pos + 2
+		for end < len(p.input) && isOptionItemByte(p.input[end]) {
+			end++
+		}
+		if end == p.pos+2 {
+			return nil // Not an option item
+		}
+		name := p.input[p.pos+2 : end]
+
+		closing := strings.IndexByte(p.input[end:], ')')
+		if closing == -1 {
+			return p.errorf(ErrInvalidOptionItem, p.pos, "unclosed option item (*%s", name)
+		}
+		arg := p.input[end : end+closing]
+
+		switch name {
+		case "LIMIT_MATCH", "LIMIT_DEPTH", "LIMIT_RECURSION":
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "="))
+			if !strings.HasPrefix(arg, "=") || err != nil || n < 1 {
+				return p.errorf(ErrInvalidOptionItem, p.pos, "option item (*%s) needs a positive value, as in (*%s=1000)", name, name)
+			}
+			if name == "LIMIT_MATCH" {
+				p.start.matchLimit = minLimit(p.start.matchLimit, n)
+			} else {
+				p.start.depthLimit = minLimit(p.start.depthLimit, n)
+			}
+		case "NO_START_OPT", "UTF", "UTF8", "UCP":
+			if arg != "" {
+				return p.errorf(ErrInvalidOptionItem, p.pos, "option item (*%s) does not take a value", name)
+			}
+			switch name {
+			case "NO_START_OPT":
+				p.start.noStartOpt = true
+			case "UCP":
+				p.start.ucp = true
+				p.flags.ucp = true
+			}
+			// Patterns are always UTF-8, so (*UTF) has nothing to change
+		default:
+			if what, ok := unsupportedItems[name]; ok {
+				return p.errorf(ErrUnsupported, p.pos, "%s (*%s) are not supported", what, name)
+			}
+			if _, ok := unsupportedVerbs[name]; ok {
+				return p.errorf(ErrUnsupported, p.pos, "backtracking control verbs such as (*%s) are not supported", name)
+			}
+			return p.errorf(ErrInvalidOptionItem, p.pos, "unknown option item (*%s)", name)
+		}
+		p.pos = end + closing + 1
+	}
+	return nil
+}
+
+// unsupportedItems lists the PCRE2 option items gore does not implement.
+var unsupportedItems = map[string]string{
+	"CR":                "newline conventions",
+	"LF":                "newline conventions",
+	"CRLF":              "newline conventions",
+	"ANYCRLF":           "newline conventions",
+	"ANY":               "newline conventions",
+	"NUL":               "newline conventions",
+	"BSR_ANYCRLF":       "\\R conventions",
+	"BSR_UNICODE":       "\\R conventions",
+	"LIMIT_HEAP":        "heap limits",
+	"NOTEMPTY":          "empty match options",
+	"NOTEMPTY_ATSTART":  "empty match options",
+	"NO_AUTO_POSSESS":   "optimization options",
+	"NO_DOTSTAR_ANCHOR": "optimization options",
+	"NO_JIT":            "optimization options",
+}
+
+// unsupportedVerbs lists the PCRE2 backtracking control verbs.
+var unsupportedVerbs = map[string]bool{
+	"ACCEPT": true, "FAIL": true, "F": true, "COMMIT": true, "PRUNE": true,
+	"SKIP": true, "THEN": true, "MARK": true, "": true,
+}
+
+// isOptionItemByte reports whether b can appear in the name of an option item.
+func isOptionItemByte(b byte) bool {
+	return (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9') || b == '_'
+}
+
 // parseExpr handles alternation: term | term
 func (p *Parser) parseExpr() (Node, error) {
 	left, err := p.parseTerm()
@@ -78,7 +220,7 @@ func (p *Parser) parseExpr() (Node, error) {
 // parseTerm handles concatenation: factor factor
 func (p *Parser) parseTerm() (Node, error) {
 	var nodes []Node
-	for p.pos < len(p.input) && p.peek() != '|' && p.peek() != ')' {
+	for p.skipExtended(); p.pos < len(p.input) && p.peek() != '|' && p.peek() != ')'; p.skipExtended() {
 		node, err := p.parseFactor()
 		if err != nil {
 			return nil, err
@@ -91,22 +233,42 @@ func (p *Parser) parseTerm() (Node, error) {
 	return &Concat{Nodes: nodes}, nil
 }
 
+// skipExtended skips whitespace and # comments in extended mode.
+func (p *Parser) skipExtended() {
+	for p.flags.extended && p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case ' ', '\t', '\n', '\v', '\f', '\r':
+			p.pos++
+		case '#':
+			if nl := strings.IndexByte(p.input[p.pos:], '\n'); nl != -1 {
+				p.pos += nl + 1
+			} else {
+				p.pos = len(p.input)
+			}
+		default:
+			return
+		}
+	}
+}
+
 // parseFactor handles quantifiers: atom*, atom+, atom?
 func (p *Parser) parseFactor() (Node, error) {
 	atom, err := p.parseAtom()
 	if err != nil {
 		return nil, err
 	}
+	p.skipExtended()
 
 	if p.pos >= len(p.input) {
 		return atom, nil
 	}
 
+	start := p.pos
 	ch := p.peek()
 	switch ch {
 	case '*', '+', '?':
 		p.consume()
-		q := &Quantifier{Body: atom, Greedy: true}
+		q := &Quantifier{Body: atom, Greedy: !p.flags.ungreedy}
 		switch ch {
 		case '*':
 			q.Min, q.Max = 0, -1
@@ -117,7 +279,10 @@ func (p *Parser) parseFactor() (Node, error) {
 		}
 		if p.pos < len(p.input) && p.peek() == '?' {
 			p.consume()
-			q.Greedy = false
+			q.Greedy = p.flags.ungreedy
+		}
+		if p.peek() == '+' {
+			return nil, p.errorf(ErrUnsupported, p.pos, "possessive quantifiers are not supported")
 		}
 		return q, nil
 	case '{':
@@ -129,11 +294,11 @@ func (p *Parser) parseFactor() (Node, error) {
 			minStr += string(p.consume())
 		}
 		if minStr == "" {
-			return nil, fmt.Errorf("invalid quantifier: missing number")
+			return nil, p.errorf(ErrInvalidRepeat, start, "invalid quantifier: missing number")
 		}
 		min, err := strconv.Atoi(minStr)
 		if err != nil {
-			return nil, fmt.Errorf("invalid quantifier: %v", err)
+			return nil, p.errorf(ErrInvalidRepeatSize, start, "invalid quantifier: %v", err)
 		}
 
 		max := min // Default: exactly n
@@ -151,29 +316,32 @@ func (p *Parser) parseFactor() (Node, error) {
 					maxStr += string(p.consume())
 				}
 				if maxStr == "" {
-					return nil, fmt.Errorf("invalid quantifier: missing max")
+					return nil, p.errorf(ErrInvalidRepeat, start, "invalid quantifier: missing max")
 				}
 				max, err = strconv.Atoi(maxStr)
 				if err != nil {
-					return nil, fmt.Errorf("invalid quantifier: %v", err)
+					return nil, p.errorf(ErrInvalidRepeatSize, start, "invalid quantifier: %v", err)
 				}
 				// Validate min <= max
 				if min > max {
-					return nil, fmt.Errorf("invalid quantifier {%d,%d}: min cannot be greater than max", min, max)
+					return nil, p.errorf(ErrInvalidRepeatSize, start, "invalid quantifier {%d,%d}: min cannot be greater than max", min, max)
 				}
 			}
 		}
 
 		if p.pos >= len(p.input) || p.consume() != '}' {
-			return nil, fmt.Errorf("unclosed quantifier")
+			return nil, p.errorf(ErrInvalidRepeat, start, "unclosed quantifier")
 		}
 
-		q := &Quantifier{Body: atom, Min: min, Max: max, Greedy: true}
+		q := &Quantifier{Body: atom, Min: min, Max: max, Greedy: !p.flags.ungreedy}
 
 		// Check for non-greedy modifier
 		if p.pos < len(p.input) && p.peek() == '?' {
 			p.consume()
-			q.Greedy = false
+			q.Greedy = p.flags.ungreedy
+		}
+		if p.peek() == '+' {
+			return nil, p.errorf(ErrUnsupported, p.pos, "possessive quantifiers are not supported")
 		}
 
 		return q, nil
@@ -186,8 +354,11 @@ func (p *Parser) parseAtom() (Node, error) {
 	ch := p.peek()
 	switch ch {
 	case '(':
+		p.groups = append(p.groups, p.pos)
 		p.consume()
-		return p.parseGroup()
+		node, err := p.parseGroup()
+		p.groups = p.groups[:len(p.groups)-1]
+		return node, err
 	case '[':
 		p.consume()
 		return p.parseCharClass()
@@ -207,29 +378,22 @@ func (p *Parser) parseAtom() (Node, error) {
 	case '\\':
 		p.consume() // eat \
 		if p.pos >= len(p.input) {
-			return nil, fmt.Errorf("trailing backslash")
+			return nil, p.errorf(ErrTrailingBackslash, p.pos-1, "trailing backslash")
 		}
+		escStart := p.pos - 1
 		esc := p.consume()
 		switch esc {
 		// Character classes
-		case 'd':
-			return &CharClass{Ranges: []RuneRange{{'0', '9'}}, FoldCase: p.flags.caseInsensitive}, nil
-		case 'D':
-			return &CharClass{Ranges: []RuneRange{{'0', '9'}}, Negated: true, FoldCase: p.flags.caseInsensitive}, nil
-		case 'w':
-			return &CharClass{Ranges: []RuneRange{{'0', '9'}, {'A', 'Z'}, {'_', '_'}, {'a', 'z'}}, FoldCase: p.flags.caseInsensitive}, nil
-		case 'W':
-			return &CharClass{Ranges: []RuneRange{{'0', '9'}, {'A', 'Z'}, {'_', '_'}, {'a', 'z'}}, Negated: true, FoldCase: p.flags.caseInsensitive}, nil
-		case 's':
-			return &CharClass{Ranges: []RuneRange{{'\t', '\t'}, {'\n', '\n'}, {'\r', '\r'}, {' ', ' '}}, FoldCase: p.flags.caseInsensitive}, nil
-		case 'S':
-			return &CharClass{Ranges: []RuneRange{{'\t', '\t'}, {'\n', '\n'}, {'\r', '\r'}, {' ', ' '}}, Negated: true, FoldCase: p.flags.caseInsensitive}, nil
+		case 'd', 'w', 's':
+			return &CharClass{Ranges: p.escapeRanges(esc), FoldCase: p.flags.caseInsensitive}, nil
+		case 'D', 'W', 'S':
+			return &CharClass{Ranges: p.escapeRanges(unicode.ToLower(esc)), Negated: true, FoldCase: p.flags.caseInsensitive}, nil
 
 		// Assertions (no fold)
 		case 'b':
-			return &Assertion{Kind: AssertWordBoundary}, nil
+			return &Assertion{Kind: AssertWordBoundary, Unicode: p.flags.ucp}, nil
 		case 'B':
-			return &Assertion{Kind: AssertNotWordBoundary}, nil
+			return &Assertion{Kind: AssertNotWordBoundary, Unicode: p.flags.ucp}, nil
 		case 'A':
 			return &Assertion{Kind: AssertStringStart}, nil
 		case 'Z':
@@ -253,10 +417,32 @@ func (p *Parser) parseAtom() (Node, error) {
 		case '.', '*', '+', '?', '|', '(', ')', '[', ']', '{', '}', '^', '$', '\\':
 			return &Literal{Runes: []rune{esc}, FoldCase: p.flags.caseInsensitive}, nil
 
+		case 'k':
+			// Named backreference \k<name>, \k'name' or \k{name}
+			switch p.peek() {
+			case '<':
+				p.consume()
+				return p.parseNamedBackref('>', escStart)
+			case '\'':
+				p.consume()
+				return p.parseNamedBackref('\'', escStart)
+			case '{':
+				p.consume()
+				return p.parseNamedBackref('}', escStart)
+			}
+			if !p.lenient {
+				return nil, p.errorf(ErrInvalidEscape, escStart, "\\k must be followed by <name>, 'name' or {name}")
+			}
+			// Treat as literal
+			return &Literal{Runes: []rune{esc}, FoldCase: p.flags.caseInsensitive}, nil
+
 		default:
 			// Check for backreference \1, \2, etc.
 			if esc >= '1' && esc <= '9' {
-				return &Backreference{Index: int(esc - '0')}, nil
+				return &Backreference{Index: int(esc - '0'), Offset: escStart}, nil
+			}
+			if err := p.checkEscape(esc, escStart); err != nil {
+				return nil, err
 			}
 			// Treat as literal
 			return &Literal{Runes: []rune{esc}, FoldCase: p.flags.caseInsensitive}, nil
@@ -268,11 +454,11 @@ func (p *Parser) parseAtom() (Node, error) {
 		p.consume()
 		return &Assertion{Kind: AssertEndText, Multiline: p.flags.multiline}, nil
 	case '|', ')':
-		return nil, fmt.Errorf("unexpected meta char: %c", ch)
+		return nil, p.errorf(ErrUnexpectedChar, p.pos, "unexpected meta char: %c", ch)
 	default:
 		// Check for quantifier metacharacters without target
 		if ch == '*' || ch == '+' || ch == '?' || ch == '{' {
-			return nil, fmt.Errorf("quantifier %q requires a target", ch)
+			return nil, p.errorf(ErrMissingRepeatArgument, p.pos, "quantifier %q requires a target", ch)
 		}
 		p.consume()
 		return &Literal{Runes: []rune{ch}, FoldCase: p.flags.caseInsensitive}, nil
@@ -281,6 +467,7 @@ func (p *Parser) parseAtom() (Node, error) {
 
 func (p *Parser) parseCharClass() (Node, error) {
 	// Already consumed [
+	classStart := p.pos - 1
 	negated := false
 	if p.peek() == '^' {
 		p.consume()
@@ -301,41 +488,36 @@ func (p *Parser) parseCharClass() (Node, error) {
 		if p.peek() == '\\' && p.pos+1 < len(p.input) {
 			nextChar := p.input[p.pos+1]
 			switch nextChar {
-			case 'd':
+			case 'd', 'w', 's':
 				p.consume() // eat \
-				p.consume() // eat d
-				ranges = append(ranges, RuneRange{Lo: '0', Hi: '9'})
+				p.consume() // eat d, w or s
+				ranges = append(ranges, p.escapeRanges(rune(nextChar))...)
 				continue
 			case 'D':
 				// \D inside [] means NOT digit, but we can't easily handle negation inside class
 				// For now, treat as error or expand to many ranges
-				return nil, fmt.Errorf("\\D not supported inside character class")
-			case 'w':
-				p.consume() // eat \
-				p.consume() // eat w
-				ranges = append(ranges, RuneRange{Lo: '0', Hi: '9'})
-				ranges = append(ranges, RuneRange{Lo: 'A', Hi: 'Z'})
-				ranges = append(ranges, RuneRange{Lo: '_', Hi: '_'})
-				ranges = append(ranges, RuneRange{Lo: 'a', Hi: 'z'})
-				continue
+				return nil, p.errorf(ErrInvalidEscape, p.pos, "\\D not supported inside character class")
 			case 'W':
 				// \W inside [] is problematic
-				return nil, fmt.Errorf("\\W not supported inside character class")
-			case 's':
-				p.consume() // eat \
-				p.consume() // eat s
-				ranges = append(ranges, RuneRange{Lo: '\t', Hi: '\t'})
-				ranges = append(ranges, RuneRange{Lo: '\n', Hi: '\n'})
-				ranges = append(ranges, RuneRange{Lo: '\r', Hi: '\r'})
-				ranges = append(ranges, RuneRange{Lo: ' ', Hi: ' '})
-				continue
+				return nil, p.errorf(ErrInvalidEscape, p.pos, "\\W not supported inside character class")
 			case 'S':
 				// \S inside [] is problematic
-				return nil, fmt.Errorf("\\S not supported inside character class")
+				return nil, p.errorf(ErrInvalidEscape, p.pos, "\\S not supported inside character class")
 			}
 		}
 
-		r1 := p.consume_cc_char()
+		// POSIX classes such as [:alpha:] are only recognized to reject them
+		if !p.lenient && strings.HasPrefix(p.input[p.pos:], "[:") {
+			if end := strings.Index(p.input[p.pos+2:], ":]"); end > 0 && isPosixClassName(p.input[p.pos+2:p.pos+2+end]) {
+				return nil, p.errorf(ErrUnsupported, p.pos, "POSIX character classes are not supported")
+			}
+		}
+
+		rangeStart := p.pos
+		r1, err := p.consume_cc_char()
+		if err != nil {
+			return nil, err
+		}
 
 		// Check for range a-z
 		if p.peek() == '-' {
@@ -346,10 +528,13 @@ func (p *Parser) parseCharClass() (Node, error) {
 				ranges = append(ranges, RuneRange{Lo: '-', Hi: '-'})
 				break
 			}
-			r2 := p.consume_cc_char()
+			r2, err := p.consume_cc_char()
+			if err != nil {
+				return nil, err
+			}
 			// Validate that Lo <= Hi
 			if r1 > r2 {
-				return nil, fmt.Errorf("invalid character class range: %c-%c (start > end)", r1, r2)
+				return nil, p.errorf(ErrInvalidCharRange, rangeStart, "invalid character class range: %c-%c (start > end)", r1, r2)
 			}
 			ranges = append(ranges, RuneRange{Lo: r1, Hi: r2})
 		} else {
@@ -358,48 +543,169 @@ func (p *Parser) parseCharClass() (Node, error) {
 	}
 
 	if p.pos >= len(p.input) || p.consume() != ']' {
-		return nil, fmt.Errorf("unclosed character class")
+		return nil, p.errorf(ErrMissingBracket, classStart, "unclosed character class")
+	}
+
+	return &CharClass{Ranges: mergeRanges(ranges), Negated: negated, FoldCase: p.flags.caseInsensitive}, nil
+}
+
+// escapeRanges returns the ranges matched by \d, \w or \s. Under (*UCP) they
+// follow Unicode properties instead of ASCII.
+func (p *Parser) escapeRanges(esc rune) []RuneRange {
+	if p.flags.ucp {
+		switch esc {
+		case 'd':
+			return tableRanges(unicode.Nd)
+		case 'w':
+			return tableRanges(unicode.L, unicode.N, unicode.Mn, unicode.Pc)
+		case 's':
+			return tableRanges(unicode.White_Space)
+		}
+	}
+	switch esc {
+	case 'd':
+		return []RuneRange{{'0', '9'}}
+	case 'w':
+		return []RuneRange{{'0', '9'}, {'A', 'Z'}, {'_', '_'}, {'a', 'z'}}
+	case 's':
+		return []RuneRange{{'\t', '\t'}, {'\n', '\n'}, {'\r', '\r'}, {' ', ' '}}
+	}
+	return nil
+}
+
+// tableRanges flattens Unicode range tables into sorted, disjoint ranges.
+func tableRanges(tables ...*unicode.RangeTable) []RuneRange {
+	var ranges []RuneRange
+	add := func(lo, hi, stride rune) {
+		if stride == 1 {
+			ranges = append(ranges, RuneRange{lo, hi})
+			return
+		}
+		for r := lo; r <= hi; r += stride {
+			ranges = append(ranges, RuneRange{r, r})
+		}
+	}
+	for _, tab := range tables {
+		for _, r := range tab.R16 {
+			add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+		for _, r := range tab.R32 {
+			add(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
 	}
+	return mergeRanges(ranges)
+}
 
-	return &CharClass{Ranges: ranges, Negated: negated, FoldCase: p.flags.caseInsensitive}, nil
+// mergeRanges sorts ranges and joins the ones that overlap or touch, which
+// lets checkRanges binary search long classes.
+func mergeRanges(ranges []RuneRange) []RuneRange {
+	if len(ranges) < 2 {
+		return ranges
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Lo < ranges[j].Lo })
+	merged := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r.Lo <= last.Hi+1 {
+			if r.Hi > last.Hi {
+				last.Hi = r.Hi
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
 }
 
-func (p *Parser) consume_cc_char() rune {
+func (p *Parser) consume_cc_char() (rune, error) {
 	if p.peek() == '\\' {
+		escStart := p.pos
 		p.consume()
 		if p.pos >= len(p.input) {
-			return '\\' // Should error but gracefully return
+			return '\\', nil // Reported as an unclosed class
 		}
 		esc := p.consume()
 		// Handle common escape sequences
 		switch esc {
 		case 'n':
-			return '\n'
+			return '\n', nil
 		case 't':
-			return '\t'
+			return '\t', nil
 		case 'r':
-			return '\r'
+			return '\r', nil
 		case 'f':
-			return '\f'
+			return '\f', nil
 		case 'v':
-			return '\v'
+			return '\v', nil
+		case 'b':
+			if !p.lenient {
+				return '\b', nil // Backspace, as in PCRE2
+			}
+			return esc, nil
 		default:
 			// For other escapes, return the literal character
-			return esc
+			if err := p.checkEscape(esc, escStart); err != nil {
+				return 0, err
+			}
+			return esc, nil
 		}
 	}
-	return p.consume()
+	return p.consume(), nil
+}
+
+// unsupportedEscapes names the PCRE2 escapes gore does not implement.
+var unsupportedEscapes = map[rune]string{
+	'p': "Unicode properties", 'P': "Unicode properties",
+	'x': "hex escapes", 'o': "octal escapes", '0': "octal escapes",
+	'c': "control character escapes", 'a': "bell escapes", 'e': "escape character escapes",
+	'Q': "quoted sequences", 'E': "quoted sequences",
+	'g': "numbered references and subroutine calls",
+	'G': "match start anchors", 'K': "match resets", 'R': "newline sequences",
+	'X': "grapheme clusters", 'N': "non-newline escapes", 'C': "single code units",
+	'h': "horizontal whitespace classes", 'H': "horizontal whitespace classes",
+	'V': "vertical whitespace classes",
+}
+
+// checkEscape reports an error for the escape of a letter or digit that gore
+// does not know, which PCRE2 would either reject or give a meaning gore does
+// not implement. Escaped punctuation is always a literal. A lenient parser
+// lets the escape through as the literal letter or digit.
+func (p *Parser) checkEscape(esc rune, offset int) error {
+	if p.lenient || !(esc < utf8.RuneSelf && isIdentRune(esc) && esc != '_') {
+		return nil
+	}
+	if what, ok := unsupportedEscapes[esc]; ok {
+		return p.errorf(ErrUnsupported, offset, "%s (\\%c) are not supported", what, esc)
+	}
+	return p.errorf(ErrInvalidEscape, offset, "unknown escape \\%c", esc)
+}
+
+// isPosixClassName reports whether name is one of the POSIX class names, with
+// an optional ^ for negation.
+func isPosixClassName(name string) bool {
+	switch strings.TrimPrefix(name, "^") {
+	case "alnum", "alpha", "ascii", "blank", "cntrl", "digit", "graph",
+		"lower", "print", "punct", "space", "upper", "word", "xdigit":
+		return true
+	}
+	return false
 }
 
 func (p *Parser) parseGroup() (Node, error) {
 	// Already consumed (
+	// Check for (* alpha assertions
+	if p.peek() == '*' {
+		p.consume() // eat *
+		return p.parseAlphaGroup()
+	}
+
 	// Check for (? extensions
 	if p.peek() == '?' {
 		p.consume() // eat ?
 
-		// Check for flags: (?i) (?m) (?s) or combinations (?im) (?-i)
-		if p.pos < len(p.input) && (p.peek() == 'i' || p.peek() == 'm' ||
-			p.peek() == 's' || p.peek() == '-') {
+		// Check for flags: (?i) (?m) (?s) (?J) (?n) (?x) (?U) or combinations (?im) (?-i),
+		// but not a relative subroutine call such as (?-1)
+		if p.pos < len(p.input) && strings.ContainsRune("imsJnxU-", p.peek()) && unsupportedGroup(p.input[p.pos:]) == "" {
 			originalFlags := p.flags // Save flags before modification
 
 			turnOn := true
@@ -425,8 +731,23 @@ func (p *Parser) parseGroup() (Node, error) {
 				case 's':
 					p.consume()
 					p.flags.dotall = turnOn
+				case 'J':
+					p.consume()
+					p.flags.dupNames = turnOn
+				case 'n':
+					p.consume()
+					p.flags.noAutoCapture = turnOn
+				case 'x':
+					p.consume()
+					p.flags.extended = turnOn
+				case 'U':
+					p.consume()
+					p.flags.ungreedy = turnOn
 				default:
-					return nil, fmt.Errorf("unknown flag: %c", ch)
+					if ch == 'X' {
+						return nil, p.errorf(ErrUnsupported, p.pos, "flag %c is not supported", ch)
+					}
+					return nil, p.errorf(ErrInvalidFlag, p.pos, "unknown flag: %c", ch)
 				}
 			}
 
@@ -446,16 +767,16 @@ func (p *Parser) parseGroup() (Node, error) {
 					return nil, err
 				}
 				if p.pos >= len(p.input) || p.consume() != ')' {
-					return nil, fmt.Errorf("unclosed group")
+					return nil, p.unclosed("group")
 				}
 				return body, nil
 			}
 
-			return nil, fmt.Errorf("invalid flag syntax")
+			return nil, p.errorf(ErrInvalidFlag, p.pos, "invalid flag syntax")
 		}
 
 		if p.pos >= len(p.input) {
-			return nil, fmt.Errorf("invalid group syntax")
+			return nil, p.unclosed("group")
 		}
 
 		// Map: (?P<name>...), (?:...), (?=...), (?!...), (?<=...), (?<!...)
@@ -468,65 +789,58 @@ func (p *Parser) parseGroup() (Node, error) {
 				return nil, err
 			}
 			if p.consume() != ')' {
-				return nil, fmt.Errorf("unclosed non-capturing group")
+				return nil, p.unclosed("non-capturing group")
 			}
 			return node, nil
 
-		case 'P': // (?P<name> named group
+		case 'P': // (?P<name> named group, or (?P=name) backreference
 			p.consume()
-			if p.consume() != '<' {
-				return nil, fmt.Errorf("expected < in named group")
+			if p.peek() == '=' {
+				p.consume()
+				return p.parseNamedBackref(')', p.pos-4)
 			}
-			nameEnd := strings.IndexRune(p.input[p.pos:], '>')
-			if nameEnd == -1 {
-				return nil, fmt.Errorf("unclosed group name")
+			if p.peek() == '>' {
+				return nil, p.errorf(ErrUnsupported, p.pos-2, "subroutine calls are not supported")
 			}
-			name := p.input[p.pos : p.pos+nameEnd]
-			p.pos += nameEnd + 1 // skip name and >
-
-			// Validate name is not empty
-			if name == "" {
-				return nil, fmt.Errorf("empty capture group name")
+			if p.peek() != '<' {
+				return nil, p.errorf(ErrInvalidGroup, p.pos, "expected < in named group")
 			}
+			p.consume()
+			return p.parseNamedGroup('>', false)
 
-			// Validate name starts with letter or underscore
-			firstChar := rune(name[0])
-			if !isIdentStart(firstChar) {
-				return nil, fmt.Errorf("invalid capture group name %q: must start with letter or underscore", name)
-			}
+		case '\'': // (?'name' named group
+			p.consume()
+			return p.parseNamedGroup('\'', true)
 
-			// Validate name contains only alphanumeric and underscore
-			for _, ch := range name {
-				if !isIdentRune(ch) {
-					return nil, fmt.Errorf("invalid capture group name %q: contains invalid character %q", name, ch)
-				}
-			}
+		case '(': // (?(cond)yes|no) conditional
+			p.consume()
+			return p.parseConditional()
 
-			// Check for duplicate names
-			if existingIdx, exists := p.names[name]; exists {
-				return nil, fmt.Errorf("duplicate capture group name %q (already used for group %d)", name, existingIdx)
-			}
+		case '=': // (?= lookahead)
+			p.consume()
+			return p.parseLookaround(false, false)
 
-			p.captures++
-			idx := p.captures
-			p.names[name] = idx
+		case '!': // (?! neg lookahead)
+			p.consume()
+			return p.parseLookaround(true, false)
 
+		case '~': // (?~ absent operator)
+			p.consume()
 			node, err := p.parseExpr()
 			if err != nil {
 				return nil, err
 			}
 			if p.consume() != ')' {
-				return nil, fmt.Errorf("unclosed named group")
+				return nil, p.unclosed("absent group")
 			}
-			return &Capture{Body: node, Index: idx, Name: name}, nil
-
-		case '=': // (?= lookahead)
-			p.consume()
-			return p.parseLookaround(false, false)
+			if err := p.checkBackwards(node, "absent group"); err != nil {
+				return nil, err
+			}
+			return &Absent{Body: node}, nil
 
-		case '!': // (?! neg lookahead)
+		case '*': // (?* non-atomic lookahead)
 			p.consume()
-			return p.parseLookaround(true, false)
+			return p.parseNonAtomicLookaround(false)
 
 		case '<': // (?<= lookbehind) or (?<! neg lookbehind)
 			p.consume()
@@ -536,15 +850,36 @@ func (p *Parser) parseGroup() (Node, error) {
 				p.consume()
 			} else if p.peek() == '=' {
 				p.consume()
+			} else if p.peek() == '*' {
+				p.consume()
+				return p.parseNonAtomicLookaround(true)
+			} else if isIdentStart(p.peek()) || p.peek() == '-' {
+				// (?<name>...) or a balancing group (?<name-other>...)
+				return p.parseNamedGroup('>', true)
 			} else {
-				return nil, fmt.Errorf("invalid lookbehind syntax")
+				return nil, p.errorf(ErrInvalidGroup, p.pos, "invalid lookbehind syntax")
 			}
 			return p.parseLookaround(neg, true)
 		default:
-			return nil, fmt.Errorf("invalid group extension: ?%c", p.peek())
+			if what := unsupportedGroup(p.input[p.pos:]); what != "" {
+				return nil, p.errorf(ErrUnsupported, p.pos-1, "%s are not supported", what)
+			}
+			return nil, p.errorf(ErrInvalidGroup, p.pos, "invalid group extension: ?%c", p.peek())
 		}
 	}
 
+	// Under (?n), a plain group does not capture
+	if p.flags.noAutoCapture {
+		node, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.consume() != ')' {
+			return nil, p.unclosed("group")
+		}
+		return node, nil
+	}
+
 	// Normal capturing group
 	p.captures++
 	idx := p.captures
@@ -553,22 +888,372 @@ func (p *Parser) parseGroup() (Node, error) {
 		return nil, err
 	}
 	if p.consume() != ')' {
-		return nil, fmt.Errorf("unclosed capturing group")
+		return nil, p.unclosed("capturing group")
 	}
 	return &Capture{Body: node, Index: idx}, nil
 }
 
+// unsupportedGroup names the PCRE2 group kind that rest, the text after "(?",
+// starts, if gore does not implement it.
+func unsupportedGroup(rest string) string {
+	if rest == "" {
+		return ""
+	}
+	switch c := rest[0]; {
+	case c == '>':
+		return "atomic groups"
+	case c == '|':
+		return "branch reset groups"
+	case c == '#':
+		return "comments"
+	case c == 'R' || c == '&' || c == '+' || (c >= '0' && c <= '9') || (c == '-' && len(rest) > 1 && rest[1] >= '0' && rest[1] <= '9'):
+		return "subroutine calls and recursion"
+	case c == 'C':
+		return "callouts"
+	case c == 'X' || c == '^':
+		return "flags other than i, m, s, n, J, x and U"
+	}
+	return ""
+}
+
+// parseNamedGroup parses a named group after its opening delimiter, for
+// (?P<name>...), (?<name>...) and (?'name'...). With balancing set it also
+// accepts .NET balancing groups: (?<name-other>...) pops the most recent
+// capture of other and captures the text in between into name, and
+// (?<-other>...) only pops.
+func (p *Parser) parseNamedGroup(term rune, balancing bool) (Node, error) {
+	nameStart := p.pos
+	nameEnd := strings.IndexRune(p.input[p.pos:], term)
+	if nameEnd == -1 {
+		return nil, p.errorf(ErrInvalidGroupName, nameStart, "unclosed group name")
+	}
+	name := p.input[p.pos : p.pos+nameEnd]
+	p.pos += nameEnd + 1 // skip name and terminator
+
+	if balancing {
+		if dash := strings.IndexByte(name, '-'); dash != -1 {
+			return p.parseBalancingGroup(name[:dash], name[dash+1:], nameStart)
+		}
+	}
+
+	if err := p.validateGroupName(name, nameStart); err != nil {
+		return nil, err
+	}
+
+	// Duplicate names are only allowed under (?J). As in PCRE2, each group
+	// still has a number of its own.
+	if idxs, exists := p.names[name]; exists && !p.flags.dupNames {
+		return nil, p.errorf(ErrDuplicateGroupName, nameStart, "duplicate capture group name %q (already used for group %d)", name, idxs[0])
+	}
+	p.captures++
+	idx := p.captures
+	p.names[name] = append(p.names[name], idx)
+
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.consume() != ')' {
+		return nil, p.unclosed("named group")
+	}
+	return &Capture{Body: node, Index: idx, Name: name}, nil
+}
+
+// validateGroupName checks that name, found at offset, is a valid identifier.
+func (p *Parser) validateGroupName(name string, offset int) error {
+	// Validate name is not empty
+	if name == "" {
+		return p.errorf(ErrInvalidGroupName, offset, "empty capture group name")
+	}
+
+	// Validate name starts with letter or underscore
+	firstChar := rune(name[0])
+	if !isIdentStart(firstChar) {
+		return p.errorf(ErrInvalidGroupName, offset, "invalid capture group name %q: must start with letter or underscore", name)
+	}
+
+	// Validate name contains only alphanumeric and underscore
+	for i, ch := range name {
+		if !isIdentRune(ch) {
+			return p.errorf(ErrInvalidGroupName, offset+i, "invalid capture group name %q: contains invalid character %q", name, ch)
+		}
+	}
+	return nil
+}
+
+// lookupGroup resolves a group reference, found at offset, given by number or
+// by name. A name shared by several groups resolves to the first of them.
+func (p *Parser) lookupGroup(ref string, offset int) (int, error) {
+	idxs, err := p.lookupGroups(ref, offset)
+	if err != nil {
+		return 0, err
+	}
+	return idxs[0], nil
+}
+
+// lookupGroups is lookupGroup for references that stand for every group of
+// a shared name, in order.
+func (p *Parser) lookupGroups(ref string, offset int) ([]int, error) {
+	if ref == "" {
+		return nil, p.errorf(ErrInvalidGroupRef, offset, "missing group reference")
+	}
+	if ref[0] >= '0' && ref[0] <= '9' {
+		idx, err := strconv.Atoi(ref)
+		if err != nil || idx == 0 {
+			return nil, p.errorf(ErrInvalidGroupRef, offset, "invalid group reference %q", ref)
+		}
+		return []int{idx}, nil
+	}
+	idxs, ok := p.names[ref]
+	if !ok {
+		return nil, p.errorf(ErrInvalidGroupRef, offset, "reference to undefined group name %q", ref)
+	}
+	return idxs, nil
+}
+
+// parseNamedBackref parses the name of \k<name>, \k'name', \k{name} or
+// (?P=name), up to the terminator. The reference starts at offset.
+func (p *Parser) parseNamedBackref(term rune, offset int) (Node, error) {
+	nameStart := p.pos
+	nameEnd := strings.IndexRune(p.input[p.pos:], term)
+	if nameEnd == -1 {
+		return nil, p.errorf(ErrInvalidGroupRef, nameStart, "unclosed backreference name")
+	}
+	name := p.input[p.pos : p.pos+nameEnd]
+	p.pos += nameEnd + 1 // skip name and terminator
+
+	if err := p.validateGroupName(name, nameStart); err != nil {
+		return nil, err
+	}
+	idxs, err := p.lookupGroups(name, nameStart)
+	if err != nil {
+		return nil, err
+	}
+
+	// As in PCRE2, a name shared by several groups refers to the first of
+	// them that is set
+	var node Node = &Backreference{Index: idxs[len(idxs)-1], Offset: offset}
+	for i := len(idxs) - 2; i >= 0; i-- {
+		node = &Conditional{Group: idxs[i], Yes: &Backreference{Index: idxs[i], Offset: offset}, No: node}
+	}
+	return node, nil
+}
+
+// parseBalancingGroup parses the body of (?<name-pop>...) or (?<-pop>...),
+// whose name part starts at offset.
+func (p *Parser) parseBalancingGroup(name, popRef string, offset int) (Node, error) {
+	popOffset := offset + len(name) + 1
+	pop, err := p.lookupGroup(popRef, popOffset)
+	if err != nil {
+		return nil, err
+	}
+	ref := groupRef{index: pop, offset: popOffset}
+	if _, named := p.names[popRef]; named {
+		ref.name = popRef
+	}
+	p.pops = append(p.pops, ref)
+	p.stacked[pop] = true
+
+	idx := 0
+	if name != "" {
+		if err := p.validateGroupName(name, offset); err != nil {
+			return nil, err
+		}
+		// The receiving group may already exist; it then gains another capture
+		if idxs, exists := p.names[name]; exists {
+			idx = idxs[0]
+		} else {
+			p.captures++
+			idx = p.captures
+			p.names[name] = []int{idx}
+		}
+		p.stacked[idx] = true
+	}
+
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.consume() != ')' {
+		return nil, p.unclosed("balancing group")
+	}
+	return &Balance{Body: node, Index: idx, Pop: pop}, nil
+}
+
+// parseConditional parses (?(cond)yes|no) after the opening "(?(". The
+// condition names a group, as (?(1)...), (?(name)...), (?(<name>)...) or
+// (?('name')...), and is true while the group holds a capture.
+func (p *Parser) parseConditional() (Node, error) {
+	condStart := p.pos
+	condEnd := strings.IndexRune(p.input[p.pos:], ')')
+	if condEnd == -1 {
+		return nil, p.errorf(ErrInvalidConditional, condStart, "unclosed conditional condition")
+	}
+	ref := p.input[p.pos : p.pos+condEnd]
+	if len(ref) >= 2 && ((ref[0] == '<' && ref[len(ref)-1] == '>') || (ref[0] == '\'' && ref[len(ref)-1] == '\'')) {
+		ref = ref[1 : len(ref)-1]
+	}
+	if ref != "" && (ref[0] == '?' || ref[0] == '*') {
+		return nil, p.errorf(ErrUnsupported, condStart, "assertion conditions are not supported")
+	}
+	if _, named := p.names[ref]; !named && isRecursionCondition(ref) {
+		return nil, p.errorf(ErrUnsupported, condStart, "recursion and DEFINE conditions are not supported")
+	}
+	groups, err := p.lookupGroups(ref, condStart)
+	if err != nil {
+		return nil, err
+	}
+	p.pos += condEnd + 1 // skip condition and )
+
+	yes, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	var no Node
+	if p.pos < len(p.input) && p.peek() == '|' {
+		p.consume()
+		if no, err = p.parseTerm(); err != nil {
+			return nil, err
+		}
+		if p.pos < len(p.input) && p.peek() == '|' {
+			return nil, p.errorf(ErrInvalidConditional, p.pos, "conditional group contains more than two branches")
+		}
+	}
+	if p.consume() != ')' {
+		return nil, p.unclosed("conditional group")
+	}
+
+	// A name shared by several groups is true if any of them is set
+	for i := len(groups) - 1; i >= 0; i-- {
+		no = &Conditional{Group: groups[i], Yes: yes, No: no}
+	}
+	return no, nil
+}
+
+// isRecursionCondition reports whether ref is one of the PCRE2 conditions
+// (R), (Rn), (R&name) or (DEFINE).
+func isRecursionCondition(ref string) bool {
+	if ref == "DEFINE" || ref == "R" || strings.HasPrefix(ref, "R&") {
+		return true
+	}
+	if len(ref) < 2 || ref[0] != 'R' {
+		return false
+	}
+	_, err := strconv.Atoi(ref[1:])
+	return err == nil
+}
+
 func (p *Parser) parseLookaround(negative, behind bool) (Node, error) {
 	node, err := p.parseExpr()
 	if err != nil {
 		return nil, err
 	}
 	if p.consume() != ')' {
-		return nil, fmt.Errorf("unclosed lookaround")
+		return nil, p.unclosed("lookaround")
+	}
+	if behind {
+		if err := p.checkBackwards(node, "lookbehind"); err != nil {
+			return nil, err
+		}
 	}
 	return &Lookaround{Body: node, Negative: negative, Behind: behind}, nil
 }
 
+func (p *Parser) parseNonAtomicLookaround(behind bool) (Node, error) {
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.consume() != ')' {
+		return nil, p.unclosed("lookaround")
+	}
+	if behind {
+		if err := p.checkBackwards(node, "lookbehind"); err != nil {
+			return nil, err
+		}
+	}
+	return &Lookaround{Body: node, Behind: behind, NonAtomic: true}, nil
+}
+
+// checkBackwards rejects the body of a lookbehind or absent group, named by
+// what, with a backreference to one of its own groups. The body runs
+// backwards, so the group would be captured after the reference to its left
+// rather than before it.
+func (p *Parser) checkBackwards(body Node, what string) error {
+	groups := make(map[int]bool)
+	walkNode(body, func(n Node) {
+		if c, ok := n.(*Capture); ok {
+			groups[c.Index] = true
+		}
+	})
+	var err error
+	walkNode(body, func(n Node) {
+		if ref, ok := n.(*Backreference); ok && groups[ref.Index] && err == nil {
+			err = p.errorf(ErrUnsupported, ref.Offset, "backreferences to a group of the same %s are not supported", what)
+		}
+	})
+	return err
+}
+
+// alphaAssertions maps the PCRE2 alphabetic assertion names to their kind.
+var alphaAssertions = map[string]Lookaround{
+	"pla":                            {},
+	"positive_lookahead":             {},
+	"nla":                            {Negative: true},
+	"negative_lookahead":             {Negative: true},
+	"plb":                            {Behind: true},
+	"positive_lookbehind":            {Behind: true},
+	"nlb":                            {Negative: true, Behind: true},
+	"negative_lookbehind":            {Negative: true, Behind: true},
+	"napla":                          {NonAtomic: true},
+	"non_atomic_positive_lookahead":  {NonAtomic: true},
+	"naplb":                          {Behind: true, NonAtomic: true},
+	"non_atomic_positive_lookbehind": {Behind: true, NonAtomic: true},
+}
+
+// parseAlphaGroup handles (*name:...) groups: alphabetic assertions and
+// script runs.
+func (p *Parser) parseAlphaGroup() (Node, error) {
+	// Already consumed (*
+	end := p.pos
+	for end < len(p.input) && isIdentRune(rune(p.input[end])) {
+		end++
+	}
+	if unsupportedVerbs[p.input[p.pos:end]] && end < len(p.input) && (p.input[end] == ')' || p.input[end] == ':') {
+		return nil, p.errorf(ErrUnsupported, p.pos-2, "backtracking control verbs such as (*%s) are not supported", p.input[p.pos:end])
+	}
+	if end == len(p.input) || p.input[end] != ':' {
+		return nil, p.errorf(ErrInvalidGroup, p.pos, "invalid (* group syntax")
+	}
+	colon := end - p.pos
+	name := p.input[p.pos:end]
+
+	switch name {
+	case "sr", "script_run", "asr", "atomic_script_run":
+		p.pos += colon + 1 // skip name and :
+		node, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.consume() != ')' {
+			return nil, p.unclosed("script run")
+		}
+		atomic := name == "asr" || name == "atomic_script_run"
+		return &ScriptRun{Body: node, Atomic: atomic}, nil
+	}
+
+	kind, ok := alphaAssertions[name]
+	if !ok {
+		return nil, p.errorf(ErrInvalidGroup, p.pos, "unknown (* group: %q", name)
+	}
+	p.pos += colon + 1 // skip name and :
+
+	if kind.NonAtomic {
+		return p.parseNonAtomicLookaround(kind.Behind)
+	}
+	return p.parseLookaround(kind.Negative, kind.Behind)
+}
+
 // Helpers
 
 func (p *Parser) peek() rune {
@@ -0,0 +1,272 @@
+package gore
+
+import "sync"
+
+// The Pike VM runs every thread of a program in lock step, one input
+// position at a time, as RE2 and Go's regexp do. Threads that reach the same
+// instruction at the same position are merged, keeping the one with the
+// highest priority, so a search takes time linear in the input whatever the
+// pattern. It can only run regular programs: those without backreferences,
+// lookaround or any other instruction that needs more state than the
+// registers of a thread.
+
+// Engine identifies the engine that runs a compiled pattern.
+type Engine int
+
+const (
+	// EngineAuto lets Compile choose: the standard library where it means
+	// the same, then the one-pass engine where it can run the pattern, the
+	// Pike VM for other patterns that only use regular constructs, and the
+	// backtracker for the rest.
+	EngineAuto Engine = iota
+
+	// EngineBacktrack is the backtracking VM, which supports every feature
+	// but can take exponential time on some patterns.
+	EngineBacktrack
+
+	// EnginePike is the Pike VM, which takes time linear in the input but
+	// cannot run backreferences, lookaround, atomic or absent groups,
+	// balancing groups, conditionals or large counted repetitions.
+	EnginePike
+
+	// EngineStdlib hands every call to the standard library's regexp,
+	// which Compile does for patterns that RE2 supports with the same
+	// meaning unless Options.NoStdlib is set. The calls it cannot take, such
+	// as those on an io.Reader, run on the engine Compile would otherwise
+	// have picked.
+	EngineStdlib
+
+	// EngineOnePass runs anchored patterns that never have two ways to go
+	// on the same rune, such as ^\d{4}-\d{2}-\d{2}$, as a single thread
+	// with no backtracking.
+	EngineOnePass
+)
+
+func (e Engine) String() string {
+	switch e {
+	case EngineAuto:
+		return "auto"
+	case EngineBacktrack:
+		return "backtrack"
+	case EnginePike:
+		return "pike"
+	case EngineOnePass:
+		return "onepass"
+	case EngineStdlib:
+		return "stdlib"
+	}
+	return "unknown"
+}
+
+// Engine returns the engine that runs re.
+func (re *Regexp) Engine() Engine {
+	if re.std != nil {
+		return EngineStdlib
+	}
+	return re.engine
+}
+
+// isRegular reports whether the Pike VM can run prog.
+func (prog *Prog) isRegular() bool {
+	for _, inst := range prog.Insts {
+		switch inst.Op {
+		case OpMatch, OpChar, OpCharClass, OpAny, OpJmp, OpSplit, OpSave, OpAssert:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// pikeThread is an entry in a run queue. Threads at instructions that
+// consume input, or at OpMatch, own a copy of the registers. The other
+// instructions are queued without registers, only so that each is followed
+// once per position.
+type pikeThread struct {
+	pc   int
+	caps []int
+}
+
+// pikeQueue is a sparse set of threads, in priority order.
+type pikeQueue struct {
+	sparse []int
+	dense  []pikeThread
+}
+
+func (q *pikeQueue) contains(pc int) bool {
+	i := q.sparse[pc]
+	return i < len(q.dense) && q.dense[i].pc == pc
+}
+
+// pikeState is the Pike VM's working memory. Each Regexp keeps a pool of
+// them, so that steady-state matching does not allocate.
+type pikeState struct {
+	clist, nlist pikeQueue
+	free         [][]int // Spare registers for threads
+	caps         []int   // Registers of the thread being followed
+	matched      []int   // Registers of the best match so far
+}
+
+// newPikePool returns a pool of working memory for running prog.
+func newPikePool(prog *Prog) *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			n := len(prog.Insts)
+			return &pikeState{
+				clist:   pikeQueue{sparse: make([]int, n), dense: make([]pikeThread, 0, n)},
+				nlist:   pikeQueue{sparse: make([]int, n), dense: make([]pikeThread, 0, n)},
+				caps:    make([]int, prog.NumRegs),
+				matched: make([]int, prog.NumRegs),
+			}
+		},
+	}
+}
+
+// alloc returns spare registers holding a copy of caps.
+func (p *pikeState) alloc(caps []int) []int {
+	var c []int
+	if n := len(p.free); n > 0 {
+		c, p.free = p.free[n-1], p.free[:n-1]
+	} else {
+		c = make([]int, len(caps))
+	}
+	copy(c, caps)
+	return c
+}
+
+// clear empties q, keeping the registers of its threads for reuse.
+func (p *pikeState) clear(q *pikeQueue) {
+	for _, t := range q.dense {
+		if t.caps != nil {
+			p.free = append(p.free, t.caps)
+		}
+	}
+	q.dense = q.dense[:0]
+}
+
+// findPike is find for regular programs. It returns the registers of the
+// leftmost-first match that starts at or after pos, or nil if there is none.
+// The registers are only valid until the next search on vm.
+func (re *Regexp) findPike(vm *VM, pos int) ([]int, error) {
+	p := re.pikePool.Get().(*pikeState)
+	vm.pike = p
+	defer func() {
+		vm.pike = nil
+		re.pikePool.Put(p)
+	}()
+
+	prog, input := vm.prog, vm.input
+	inputLen := input.Len()
+	interruptible := vm.ctx != nil || !vm.deadline.IsZero()
+
+	p.clear(&p.clist)
+	p.clear(&p.nlist)
+	matched := false
+	for step := 0; ; step++ {
+		if interruptible && step&(interruptInterval-1) == 0 && vm.checkInterrupt() {
+			p.clear(&p.clist)
+			return nil, vm.err
+		}
+
+		if !matched {
+			// With no threads running, skip to where the prefix occurs next
+			if len(p.clist.dense) == 0 && prog.canSkip() {
+				if pos = input.Index(re, pos); pos == -1 {
+					break
+				}
+			}
+
+			// A thread starting here has the lowest priority
+			for i := range p.caps {
+				p.caps[i] = -1
+			}
+			vm.pikeAdd(&p.clist, prog.Start, pos, p.caps)
+		}
+		if len(p.clist.dense) == 0 {
+			break
+		}
+
+		r, w := input.Step(pos)
+	threads:
+		for i := range p.clist.dense {
+			t := p.clist.dense[i]
+			if t.caps == nil {
+				continue
+			}
+			inst := &prog.Insts[t.pc]
+			ok := false
+			switch inst.Op {
+			case OpMatch:
+				// Threads after this one have lower priority, so they can
+				// only lead to matches that lose to it
+				copy(p.matched, t.caps)
+				matched = true
+				p.clear(&p.clist)
+				break threads
+			case OpChar:
+				if inst.FoldCase {
+					ok = w > 0 && simpleFoldEqual(r, inst.Val)
+				} else {
+					ok = w > 0 && r == inst.Val
+				}
+			case OpCharClass:
+				ok = w > 0 && matchClass(r, inst.Ranges, inst.Negated, inst.FoldCase)
+			case OpAny:
+				ok = w > 0 && r != '\n'
+			}
+			if ok {
+				vm.pikeAdd(&p.nlist, t.pc+1, pos+w, t.caps)
+			}
+		}
+		p.clear(&p.clist)
+		p.clist, p.nlist = p.nlist, p.clist
+
+		if w == 0 || pos >= inputLen {
+			p.clear(&p.clist)
+			break
+		}
+		pos += w
+	}
+
+	if !matched {
+		return nil, nil
+	}
+	if cap(vm.caps) < len(p.matched) {
+		vm.caps = make([]int, len(p.matched))
+	}
+	vm.caps = vm.caps[:len(p.matched)]
+	copy(vm.caps, p.matched)
+	return vm.caps, nil
+}
+
+// pikeAdd queues the thread at pc on q, following the instructions that do
+// not consume input, in priority order, to the ones that do. caps holds the
+// thread's registers; it is restored before pikeAdd returns.
+func (vm *VM) pikeAdd(q *pikeQueue, pc, pos int, caps []int) {
+	if q.contains(pc) {
+		return
+	}
+	i := len(q.dense)
+	q.sparse[pc] = i
+	q.dense = append(q.dense, pikeThread{pc: pc})
+
+	inst := &vm.prog.Insts[pc]
+	switch inst.Op {
+	case OpJmp:
+		vm.pikeAdd(q, inst.Out, pos, caps)
+	case OpSplit:
+		vm.pikeAdd(q, inst.Out, pos, caps)
+		vm.pikeAdd(q, inst.Out1, pos, caps)
+	case OpSave:
+		old := caps[inst.Idx]
+		caps[inst.Idx] = pos
+		vm.pikeAdd(q, pc+1, pos, caps)
+		caps[inst.Idx] = old
+	case OpAssert:
+		if vm.checkAssertion(inst.Assert, pos, inst.Multiline, inst.Unicode) {
+			vm.pikeAdd(q, pc+1, pos, caps)
+		}
+	default:
+		q.dense[i].caps = vm.pike.alloc(caps)
+	}
+}
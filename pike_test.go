@@ -0,0 +1,180 @@
+package gore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEngineSelection tests which of gore's own engines Compile picks
+func TestEngineSelection(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    Engine
+	}{
+		{`abc`, EnginePike},
+		{`(a+)+b`, EnginePike},
+		{`(?i)(\w+)@(\w+)\.com$`, EnginePike},
+		{`^(?i)(\w+)@(\w+)\.com$`, EngineOnePass},
+		{`^\d{4}-\d{2}-\d{2}$`, EngineOnePass},
+		{`^(a|ab)$`, EnginePike},
+		{`(?m)^a|b$|\bc\B`, EnginePike},
+		{`a{2,5}?`, EnginePike},
+		{`(a)\1`, EngineBacktrack},
+		{`a(?=b)`, EngineBacktrack},
+		{`(?<!a)b`, EngineBacktrack},
+		{`(?~ab)`, EngineBacktrack},
+		{`(?<o>a)(?<-o>b)`, EngineBacktrack},
+		{`(a)?(?(1)b|c)`, EngineBacktrack},
+		{`a{100}`, EnginePike},
+		{`\d{10}`, EnginePike},
+		{`^\d{10}$`, EngineOnePass},
+		{`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`, EnginePike},
+		{`a{1001}`, EngineBacktrack},
+		{`(a)\1{10}`, EngineBacktrack},
+	}
+	for _, tc := range tests {
+		if got := MustCompileWithOptions(tc.pattern, Options{NoStdlib: true}).Engine(); got != tc.want {
+			t.Errorf("Compile(%q).Engine() = %v; want %v", tc.pattern, got, tc.want)
+		}
+	}
+
+	if got := MustCompileWithOptions(`abc`, Options{Engine: EngineBacktrack}).Engine(); got != EngineBacktrack {
+		t.Errorf("forced backtracking: Engine() = %v", got)
+	}
+	if _, err := CompileWithOptions(`\d{10}`, Options{Engine: EnginePike}); err != nil {
+		t.Errorf("forced Pike VM on a count: err = %v", err)
+	}
+	var err *Error
+	if _, compileErr := CompileWithOptions(`(a)\1`, Options{Engine: EnginePike}); !errors.As(compileErr, &err) || err.Code != ErrUnsupported {
+		t.Errorf("forced Pike VM on a backreference: err = %v; want ErrUnsupported", compileErr)
+	}
+}
+
+// TestPikeMatchesBacktrack tests that both engines find the same matches
+// and submatches
+func TestPikeMatchesBacktrack(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+	}{
+		{`a|ab`, "abab"},
+		{`(a|ab)(c|bcd)(d*)`, "abcd"},
+		{`(a+)(b+)?`, "aaabbb aab a"},
+		{`(a*?)(a*)`, "aaaa"},
+		{`(\w+)\s*=\s*(\w*)`, "key = value; k2=; x =y"},
+		{`(?i)(hello) (WORLD)`, "say Hello world and HELLO WORLD"},
+		{`(?m)^(\w+)$`, "one\ntwo\n\nthree"},
+		{`(?s)a.b|a(.)c`, "a\nb axc"},
+		{`\b(\w)(\w*)\b`, "the quick brown fox"},
+		{`(x)?(y)?z`, "z xz yz xyz"},
+		{`(a|b)*?c`, "ababc abc c"},
+		{`((a)|(b))+`, "abba"},
+		{`(\d{2,4})-(\d{1,2})`, "2024-1 99-12 1-1"},
+		{`(?U)(a+)(a+?)`, "aaaa"},
+		{`[^aeiou\s]+`, "rhythm and blues"},
+		{``, "abc"},
+		{`é+|(ü)`, "café ü éé"},
+		{`(a*)+$`, "ab"},
+		{`(a*)*$`, "ab"},
+		{`(a|)+?b`, "aab"},
+		{`(a|b?)+c`, "abc bc"},
+		{`(?:a?){12,}`, "aa"},
+		{`(a?){12,}b`, "aab"},
+		{`(?:[^a]*?)*`, "c1"},
+		{`(?mi)(b?(?:c?|b*[^a]?.))*a*|[ab]*b+`, "bac1 "},
+		{`(?:b?|x)*`, "bx"},
+		{`((x*)*)*y`, "xxy xy"},
+	}
+	for _, tc := range tests {
+		pike := MustCompileWithOptions(tc.pattern, Options{NoStdlib: true})
+		if pike.Engine() != EnginePike {
+			t.Errorf("%s: not compiled for the Pike VM", tc.pattern)
+			continue
+		}
+		bt := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack})
+
+		if got, want := pike.FindAllStringSubmatch(tc.input, -1), bt.FindAllStringSubmatch(tc.input, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAllStringSubmatch(%q, %q) = %q; backtracker gives %q", tc.pattern, tc.input, got, want)
+		}
+		if got, want := pike.FindAllStringIndex(tc.input, -1), bt.FindAllStringIndex(tc.input, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAllStringIndex(%q, %q) = %v; backtracker gives %v", tc.pattern, tc.input, got, want)
+		}
+	}
+}
+
+// TestEmptyIterations tests that every engine leaves a loop after an
+// iteration that matches empty and goes on after it, as PCRE2 does
+func TestEmptyIterations(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    []string
+	}{
+		{`(?:[^a]*?)*`, "c1", []string{""}},
+		{`(?mi)(b?(?:c?|b*[^a]?.))*a*|[ab]*b+`, "bac1 ", []string{"ba", ""}},
+		{`(?:b?|x)*`, "bx", []string{"b"}},
+		{`(a|b?)+c`, "abc", []string{"abc", ""}},
+		{`(x*)*$`, "xxx", []string{"xxx", ""}},
+		{`((x*)*)*y`, "xxy", []string{"xxy", "", ""}},
+		{`(?:(?=a)|b)*(\w)`, "bba", []string{"bba", "a"}},
+	}
+	for _, tc := range tests {
+		for _, opts := range []Options{{Engine: EngineBacktrack}, {Engine: EngineBacktrack, Memoize: true}, {NoStdlib: true}} {
+			re := MustCompileWithOptions(tc.pattern, opts)
+			if got := re.FindStringSubmatch(tc.input); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("%s on %q (%v, memoized %v): got %q; want %q", tc.pattern, tc.input, re.Engine(), opts.Memoize, got, tc.want)
+			}
+		}
+	}
+
+	// Copying the bodies of loops nested this deep would outgrow
+	// MaxProgSize, so the pattern runs on the backtracker
+	deep := MustCompile(strings.Repeat("(?:", 20) + "a?" + strings.Repeat(")*", 20))
+	if got := deep.FindString("aab"); got != "aa" {
+		t.Errorf("deeply nested loops: FindString = %q; want %q", got, "aa")
+	}
+}
+
+// TestPikeLinearTime tests patterns that are exponential for a backtracker
+func TestPikeLinearTime(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    string
+	}{
+		{`(a+)+b`, strings.Repeat("a", 10000), ""},
+		{`(a|aa)+$`, strings.Repeat("a", 10000) + "!", ""},
+		{`^(\w+\s?)*$`, strings.Repeat("word ", 2000) + "!", ""},
+		{`(x+x+)+y`, strings.Repeat("x", 5000) + "y", strings.Repeat("x", 5000) + "y"},
+		// Loops whose body can match empty
+		{`(|a)+b`, strings.Repeat("a", 5000) + "b", strings.Repeat("a", 5000) + "b"},
+		{`(a*)+$`, strings.Repeat("a", 5000) + "b", ""},
+	}
+	for _, tc := range tests {
+		start := time.Now()
+		got, err := MustCompile(tc.pattern).FindStringErr(tc.input)
+		if got != tc.want || err != nil {
+			t.Errorf("FindStringErr(%q) = %.20q, %v; want %.20q, nil", tc.pattern, got, err, tc.want)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("%s: took %v", tc.pattern, elapsed)
+		}
+	}
+}
+
+// TestPikeContext tests that the Pike VM stops when its context is done
+func TestPikeContext(t *testing.T) {
+	re := MustCompile(`(a|b)*c`)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := re.MatchStringContext(canceled, strings.Repeat("ab", 1000)); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v; want context.Canceled", err)
+	}
+	if m, err := re.FindStringContext(context.Background(), "xabc"); m != "abc" || err != nil {
+		t.Errorf("FindStringContext = %q, %v; want \"abc\", nil", m, err)
+	}
+}
@@ -0,0 +1,379 @@
+package gore
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A prefilter lets a search skip the positions where no match can start,
+// for patterns that have no literal prefix. It is worked out in one of two
+// ways:
+//   - From the AST, as a small set of literals one of which begins every
+//     match, as for (GET|POST) / or (?i)error. Case-insensitive literals
+//     and small classes are expanded into every form they match.
+//   - Failing that, from the program, as the set of bytes a match can
+//     start with, as for [A-Z]\w+.
+//
+// The search looks for the next byte that can start a match with a skip
+// loop, and checks the literals there by walking a trie of them. A pattern
+// that can match the empty string, or only at the start of the input, has
+// no prefilter.
+
+// maxPrefilterLiterals is the most literals a prefilter looks for.
+const maxPrefilterLiterals = 64
+
+// maxClassLiterals is the most runes a class may match to be expanded into
+// literals; larger classes leave the prefilter to the bytes.
+const maxClassLiterals = 16
+
+// maxPrefilterBytes is the most bytes that may start a match for the byte
+// set to be worth scanning with.
+const maxPrefilterBytes = 160
+
+// prefilter finds where a match can start next.
+type prefilter struct {
+	first  [256]bool // Bytes a match can start with
+	single int       // The only such byte, or -1
+	trie   []trieNode
+}
+
+// trieNode is a node of the trie of the prefilter's literals. The root is
+// node 0.
+type trieNode struct {
+	bytes []byte  // Bytes with an edge, in order
+	next  []int32 // Node each of them leads to
+	final bool    // A literal ends here
+}
+
+// analyzePrefilter returns the prefilter of a pattern without a literal
+// prefix, or nil if there is nothing worth filtering on.
+func (c *Compiler) analyzePrefilter(node Node, prog *Prog) *prefilter {
+	if lits, ok := leadingLiterals(node); ok && !slices.ContainsFunc(lits, func(l literal) bool { return l.s == "" }) {
+		pf := newLiteralPrefilter(lits)
+		if pf.count() <= maxPrefilterBytes {
+			return pf
+		}
+	}
+	if pf := firstBytes(prog); pf != nil && pf.count() <= maxPrefilterBytes {
+		return pf
+	}
+	return nil
+}
+
+// literal is text that begins a match of a node. A complete literal is the
+// whole of a match, so what follows the node can extend it.
+type literal struct {
+	s        string
+	complete bool
+}
+
+// leadingLiterals returns literals one of which begins every match of node,
+// or false if it cannot tell. A literal "" means a match can start with
+// anything.
+func leadingLiterals(node Node) ([]literal, bool) {
+	switch n := node.(type) {
+	case *Literal:
+		lits := []literal{{"", true}}
+		for _, r := range n.Runes {
+			forms := []rune{r}
+			if n.FoldCase {
+				for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+					forms = append(forms, f)
+				}
+			}
+			// RuneError also matches invalid UTF-8, which no literal spells
+			if slices.Contains(forms, utf8.RuneError) || len(lits)*len(forms) > maxPrefilterLiterals {
+				return incomplete(lits), true
+			}
+			lits = concatLiterals(lits, runeLiterals(forms))
+		}
+		return lits, true
+
+	case *CharClass:
+		set := runeSet(&Inst{Op: OpCharClass, Ranges: n.Ranges, Negated: n.Negated, FoldCase: n.FoldCase})
+		var runes []rune
+		for _, rng := range set {
+			if int(rng.Hi-rng.Lo)+1 > maxClassLiterals-len(runes) {
+				return nil, false
+			}
+			for r := rng.Lo; r <= rng.Hi; r++ {
+				runes = append(runes, r)
+			}
+		}
+		if len(runes) == 0 || slices.Contains(runes, utf8.RuneError) {
+			return nil, false
+		}
+		return runeLiterals(runes), true
+
+	case *Concat:
+		lits := []literal{{"", true}}
+		for _, sub := range n.Nodes {
+			if !slices.ContainsFunc(lits, func(l literal) bool { return l.complete }) {
+				break
+			}
+			next, ok := leadingLiterals(sub)
+			if !ok {
+				return incomplete(lits), true
+			}
+			product := concatLiterals(lits, next)
+			if len(product) > maxPrefilterLiterals {
+				return incomplete(lits), true
+			}
+			lits = product
+		}
+		return lits, true
+
+	case *Alternate:
+		var lits []literal
+		for _, sub := range n.Nodes {
+			next, ok := leadingLiterals(sub)
+			if !ok {
+				return nil, false
+			}
+			lits = unionLiterals(lits, next)
+			if len(lits) > maxPrefilterLiterals {
+				return nil, false
+			}
+		}
+		if len(lits) == 0 {
+			return nil, false
+		}
+		return lits, true
+
+	case *Capture:
+		return leadingLiterals(n.Body)
+
+	case *Quantifier:
+		lits, ok := leadingLiterals(n.Body)
+		if !ok {
+			return nil, false
+		}
+		if n.Max == 0 {
+			return []literal{{"", true}}, true
+		}
+		if n.Max != 1 {
+			lits = incomplete(lits) // More repetitions may follow
+		}
+		if n.Min == 0 {
+			lits = unionLiterals(lits, []literal{{"", true}})
+		}
+		return lits, true
+
+	case *Assertion:
+		// A pattern anchored at the start has nothing to skip to
+		if n.Kind == AssertStringStart || n.Kind == AssertStartText && !n.Multiline {
+			return nil, false
+		}
+		return []literal{{"", true}}, true
+
+	case *Lookaround:
+		return []literal{{"", true}}, true
+	}
+	return nil, false
+}
+
+// runeLiterals returns a complete literal for each rune.
+func runeLiterals(runes []rune) []literal {
+	lits := make([]literal, len(runes))
+	for i, r := range runes {
+		lits[i] = literal{string(r), true}
+	}
+	return lits
+}
+
+// incomplete returns lits marked as incomplete.
+func incomplete(lits []literal) []literal {
+	out := make([]literal, len(lits))
+	for i, l := range lits {
+		out[i] = literal{l.s, false}
+	}
+	return out
+}
+
+// concatLiterals returns the literals that begin a match of one node
+// followed by another, extending the complete literals of the first by
+// those of the second.
+func concatLiterals(first, second []literal) []literal {
+	var out []literal
+	for _, a := range first {
+		if !a.complete {
+			out = unionLiterals(out, []literal{a})
+			continue
+		}
+		for _, b := range second {
+			out = unionLiterals(out, []literal{{a.s + b.s, b.complete}})
+		}
+	}
+	return out
+}
+
+// unionLiterals adds the literals of b missing from a.
+func unionLiterals(a, b []literal) []literal {
+	for _, l := range b {
+		if !slices.Contains(a, l) {
+			a = append(a, l)
+		}
+	}
+	return a
+}
+
+// newLiteralPrefilter returns a prefilter that looks for lits.
+func newLiteralPrefilter(lits []literal) *prefilter {
+	pf := &prefilter{trie: []trieNode{{}}}
+	for _, l := range lits {
+		pf.first[l.s[0]] = true
+		node := 0
+		for i := 0; i < len(l.s); i++ {
+			b := l.s[i]
+			j, found := slices.BinarySearch(pf.trie[node].bytes, b)
+			if !found {
+				pf.trie = append(pf.trie, trieNode{})
+				n := &pf.trie[node]
+				n.bytes = slices.Insert(n.bytes, j, b)
+				n.next = slices.Insert(n.next, j, int32(len(pf.trie)-1))
+			}
+			node = int(pf.trie[node].next[j])
+		}
+		pf.trie[node].final = true
+	}
+	pf.setSingle()
+	return pf
+}
+
+// firstBytes returns a prefilter on the bytes a match of prog can start
+// with, or nil if a match can start with anything.
+func firstBytes(prog *Prog) *prefilter {
+	pf := &prefilter{}
+	seen := make([]bool, len(prog.Insts))
+	var walk func(pc int) bool
+	walk = func(pc int) bool {
+		if pc >= len(prog.Insts) || seen[pc] {
+			return true
+		}
+		seen[pc] = true
+		inst := &prog.Insts[pc]
+		switch inst.Op {
+		case OpJmp:
+			return walk(inst.Out)
+		case OpSplit, OpRepeat:
+			return walk(inst.Out) && walk(inst.Out1)
+		case OpSave, OpLookaround, OpCountReset, OpProgress:
+			return walk(pc + 1)
+		case OpAssert:
+			if inst.Assert == AssertStringStart || inst.Assert == AssertStartText && !inst.Multiline {
+				return false
+			}
+			return walk(pc + 1)
+		case OpChar, OpCharClass, OpAny:
+			if inst.Reverse {
+				// The body of a lookbehind, which reads what comes before
+				return false
+			}
+			for _, rng := range runeSet(inst) {
+				if rng.Lo <= utf8.RuneError && utf8.RuneError <= rng.Hi {
+					// Invalid UTF-8 reads as RuneError
+					for b := utf8.RuneSelf; b < 256; b++ {
+						pf.first[b] = true
+					}
+				}
+				for b := leadByte(rng.Lo); b <= leadByte(rng.Hi); b++ {
+					pf.first[b] = true
+				}
+			}
+			return true
+		}
+		return false // OpMatch, or something that may match empty or is too hard to follow
+	}
+	if !walk(prog.Start) {
+		return nil
+	}
+	pf.setSingle()
+	return pf
+}
+
+// leadByte returns the first byte of the UTF-8 encoding of r, which grows
+// with r. Surrogates, which have no encoding, get the byte their
+// neighbors have.
+func leadByte(r rune) int {
+	switch {
+	case r < 0x80:
+		return int(r)
+	case r < 0x800:
+		return 0xC0 | int(r>>6)
+	case r < 0x10000:
+		return 0xE0 | int(r>>12)
+	}
+	return 0xF0 | int(r>>18)
+}
+
+// setSingle records the first byte if it is the only one.
+func (pf *prefilter) setSingle() {
+	pf.single = -1
+	if pf.count() == 1 {
+		pf.single = slices.Index(pf.first[:], true)
+	}
+}
+
+// count returns how many bytes a match can start with.
+func (pf *prefilter) count() int {
+	n := 0
+	for _, ok := range pf.first {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+// prefilterIndex returns the first position in s from pos on where a match
+// can start, or -1 if there is none.
+func prefilterIndex[T string | []byte](pf *prefilter, s T, pos int) int {
+	for pos < len(s) {
+		// Skip to the next byte that can start a match
+		switch {
+		case pf.single >= 0:
+			var i int
+			switch s := any(s[pos:]).(type) {
+			case string:
+				i = strings.IndexByte(s, byte(pf.single))
+			case []byte:
+				i = bytes.IndexByte(s, byte(pf.single))
+			}
+			if i < 0 {
+				return -1
+			}
+			pos += i
+		default:
+			for pos < len(s) && !pf.first[s[pos]] {
+				pos++
+			}
+			if pos == len(s) {
+				return -1
+			}
+		}
+		if pf.trie == nil || literalAt(pf, s[pos:]) {
+			return pos
+		}
+		pos++
+	}
+	return -1
+}
+
+// literalAt reports whether s starts with one of the literals of pf.
+func literalAt[T string | []byte](pf *prefilter, s T) bool {
+	node := &pf.trie[0]
+	for i := 0; i < len(s); i++ {
+		j, found := slices.BinarySearch(node.bytes, s[i])
+		if !found {
+			return false
+		}
+		node = &pf.trie[node.next[j]]
+		if node.final {
+			return true
+		}
+	}
+	return false
+}
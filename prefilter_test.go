@@ -0,0 +1,149 @@
+package gore
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// prefilterLiterals returns the literals in the trie of pf, in order
+func prefilterLiterals(pf *prefilter) []string {
+	var lits []string
+	var walk func(node int, prefix string)
+	walk = func(node int, prefix string) {
+		n := &pf.trie[node]
+		if n.final {
+			lits = append(lits, prefix)
+		}
+		for i, b := range n.bytes {
+			walk(int(n.next[i]), prefix+string([]byte{b}))
+		}
+	}
+	if pf.trie != nil {
+		walk(0, "")
+	}
+	return lits
+}
+
+// TestPrefilterAnalysis tests what patterns without a literal prefix
+// filter on
+func TestPrefilterAnalysis(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		literals []string // nil for a byte set
+		bytes    string   // The bytes a match can start with, "" for no prefilter
+	}{
+		{`(GET|POST) /`, []string{"GET /", "POST /"}, "GP"},
+		{`(?i)k`, []string{"K", "k", "K"}, "Kk\xe2"},
+		{`a*b`, []string{"a", "b"}, "ab"},
+		{`x?(foo|bar)`, []string{"bar", "foo", "xbar", "xfoo"}, "bfx"},
+		{`[ab]c`, []string{"ac", "bc"}, "ab"},
+		{`(?:ab)+c`, []string{"ab"}, "a"},
+		{`(?=a)[a-c]`, []string{"a", "b", "c"}, "abc"},
+		{`\bfoo`, []string{"foo"}, "f"},
+		{`[A-Z]\w+`, nil, "ABCDEFGHIJKLMNOPQRSTUVWXYZ"},
+		{`[0-9a-z]+:`, nil, "0123456789abcdefghijklmnopqrstuvwxyz"},
+		{`abc`, nil, ""},          // Has a literal prefix instead
+		{`\w*`, nil, ""},          // Can match empty
+		{`.x`, nil, ""},           // Can start with almost anything
+		{`\Aab|cd`, nil, ""},      // Anchored on a branch
+		{`^[a-z]+`, nil, ""},      // Anchored
+		{`(a)\1|\1b`, nil, ""},    // A backreference can match empty
+		{`(?<*a)[b-z]+`, nil, ""}, // Reads backwards first
+	}
+	for _, tc := range tests {
+		pf := MustCompileWithOptions(tc.pattern, Options{Engine: EngineBacktrack}).prog.prefilter
+		if tc.bytes == "" {
+			if pf != nil {
+				t.Errorf("%s: has a prefilter; want none", tc.pattern)
+			}
+			continue
+		}
+		if pf == nil {
+			t.Errorf("%s: has no prefilter", tc.pattern)
+			continue
+		}
+		var bytes []byte
+		for b, ok := range pf.first {
+			if ok {
+				bytes = append(bytes, byte(b))
+			}
+		}
+		if want := []byte(tc.bytes); !slices.Equal(bytes, want) {
+			t.Errorf("%s: starts with %q; want %q", tc.pattern, bytes, want)
+		}
+		if got := prefilterLiterals(pf); !reflect.DeepEqual(got, tc.literals) {
+			t.Errorf("%s: literals %q; want %q", tc.pattern, got, tc.literals)
+		}
+	}
+
+	// A lookbehind body is compiled backwards, and its first byte is not
+	// the first byte of the match
+	for _, opts := range []Options{{Engine: EngineBacktrack}, {NoStdlib: true}} {
+		if got := MustCompileWithOptions(`(?<*a)[b-z]+`, opts).FindString("ab"); got != "b" {
+			t.Errorf("(?<*a)[b-z]+: FindString(\"ab\") = %q; want \"b\"", got)
+		}
+	}
+
+	lits := prefilterLiterals(MustCompile(`(?i)error`).prog.prefilter)
+	if len(lits) != 32 || !slices.Contains(lits, "ErRoR") {
+		t.Errorf("(?i)error: literals %q; want its 32 case forms", lits)
+	}
+}
+
+// TestPrefilterSameResults tests that skipping ahead with the prefilter
+// finds the same matches as trying every position
+func TestPrefilterSameResults(t *testing.T) {
+	patterns := []string{
+		`(GET|POST) (/\S*)`,
+		`(?i)error: (\w+)`,
+		`[A-Z]\w+`,
+		`(?i)k\w*`,
+		`x?(foo|bar)`,
+		`a*b`,
+		`\b(ab|cd)\b`,
+		`(?m)(?<=\n)(ab|cd)`,
+		`[α-ω]+`,
+		`(é|ü)+`,
+		`(?<=a)[b-z]+`,
+	}
+	inputs := []string{
+		"",
+		"GET /index.html and POST /form, not PUT /x",
+		"ok\nERROR: disk\nerror: net\nErRoR:x",
+		"Hello World from Go",
+		"kelvin KELVIN Kelvin k",
+		"xfoo bar xxbar fo",
+		"aaab b aaa",
+		"ab cd abcd xab cd",
+		"\nab\ncd ab",
+		"ascii é über naïve αβγ",
+		"ab bab xaz",
+		"\xff\xfe invalid \xc3 GET /x",
+		strings.Repeat("no match here ", 20) + "POST /late",
+	}
+	for _, pattern := range patterns {
+		for _, opts := range []Options{{Engine: EngineBacktrack}, {NoStdlib: true}} {
+			re := MustCompileWithOptions(pattern, opts)
+			if re.prog.prefilter == nil {
+				t.Errorf("%s: has no prefilter", pattern)
+				continue
+			}
+			plain := MustCompileWithOptions(pattern, opts)
+			plain.prog.prefilter = nil
+			for _, input := range inputs {
+				if got, want := re.FindAllStringSubmatch(input, -1), plain.FindAllStringSubmatch(input, -1); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s on %v: FindAllStringSubmatch(%q) = %q; want %q", pattern, re.Engine(), input, got, want)
+				}
+				if got, want := re.FindAllStringIndex(input, -1), plain.FindAllStringIndex(input, -1); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s on %v: FindAllStringIndex(%q) = %v; want %v", pattern, re.Engine(), input, got, want)
+				}
+				got, _ := re.MatchReader(strings.NewReader(input))
+				if want := plain.MatchString(input); got != want {
+					t.Errorf("%s on %v: MatchReader(%q) = %v; want %v", pattern, re.Engine(), input, got, want)
+				}
+			}
+		}
+	}
+}
@@ -5,43 +5,77 @@ import "fmt"
 type OpCode int
 
 const (
-	OpMatch      OpCode = iota // Terminate success
-	OpChar                     // Match specific rune
-	OpCharClass                // Match char class
-	OpAny                      // Match any (dot), usually valid utf8
-	OpJmp                      // Jump to Offset
-	OpSplit                    // Splits execution (try X, else Y)
-	OpSave                     // Save position to capture register
-	OpAssert                   // Zero-width assertion (Start/End line)
-	OpLookaround               // Recursive check for lookaround
-	OpBackref                  // Match a backreference to a capture group
+	OpMatch       OpCode = iota // Terminate success
+	OpChar                      // Match specific rune
+	OpCharClass                 // Match char class
+	OpAny                       // Match any (dot), usually valid utf8
+	OpJmp                       // Jump to Offset
+	OpSplit                     // Splits execution (try X, else Y)
+	OpSave                      // Save position to capture register
+	OpAssert                    // Zero-width assertion (Start/End line)
+	OpLookaround                // Recursive check for lookaround
+	OpBackref                   // Match a backreference to a capture group
+	OpRestorePos                // Reset position to the value saved in a register
+	OpAtomic                    // Run a subprogram once and continue from where it ended
+	OpScriptRun                 // Check that the text since a saved position is a script run
+	OpAbsent                    // Match text that does not contain a match of a subprogram
+	OpPushCap                   // Push a capture onto a group's capture stack
+	OpPopCap                    // Pop the most recent capture of a group (balancing groups)
+	OpTransferCap               // Capture the text between a popped capture and the current group
+	OpCond                      // Branch on whether a capture group is set
+	OpCountReset                // Set a repeat counter register to zero
+	OpRepeat                    // Enter the body of a counted repetition again, or leave it
+	OpCountInc                  // Count one more repetition and jump back to its OpRepeat
+	OpProgress                  // Go back to the start of a loop unless the iteration matched empty
 )
 
 type Inst struct {
-	Op         OpCode
-	Val        rune          // For OpChar
-	Ranges     []RuneRange   // For OpCharClass
-	Negated    bool          // For OpCharClass
-	Out        int           // Jump target 1 (primary)
-	Out1       int           // Jump target 2 (alternative for Split)
-	Idx        int           // Register index for OpSave, or capture group for OpBackref
-	Assert     AssertionType // For OpAssert
-	Multiline  bool          // For OpAssert (multiline mode)
-	Prog       *Prog         // For OpLookaround (sub-routine)
-	LookNeg    bool          // Negative lookaround
-	LookBehind bool          // Lookbehind
-	FoldCase   bool          // Case-insensitive matching
+	Op        OpCode
+	Val       rune          // For OpChar
+	Ranges    []RuneRange   // For OpCharClass
+	Negated   bool          // For OpCharClass
+	Out       int           // Jump target 1 (primary)
+	Out1      int           // Jump target 2 (alternative for Split)
+	Idx       int           // Register index for OpSave, or capture group for OpBackref and the capture stack ops
+	Arg       int           // Scratch register for the capture stack ops
+	Assert    AssertionType // For OpAssert
+	Multiline bool          // For OpAssert (multiline mode)
+	Unicode   bool          // For OpAssert (Unicode word boundaries)
+	Prog      *Prog         // For OpLookaround, OpAtomic and OpAbsent (sub-routine)
+	LookNeg   bool          // Negative lookaround
+	FoldCase  bool          // Case-insensitive matching
+	Reverse   bool          // Match backwards from the current position (lookbehind bodies)
+	Min, Max  int           // For OpRepeat: bounds on the repetitions, Max -1 if unbounded
+	Greedy    bool          // For OpRepeat: prefer another repetition over leaving
 }
 
 // Prog is a compiled regular expression program.
 type Prog struct {
-	Insts  []Inst
-	Start  int // Entry point
-	NumCap int // Number of capture registers needed
+	Insts   []Inst
+	Start   int // Entry point
+	NumCap  int // Number of capture groups (including group 0)
+	NumRegs int // Total registers: capture pairs followed by scratch registers
+
+	// StackBase is the first of NumCap registers holding the top of each
+	// group's capture stack, or 0 if the pattern has no balancing groups.
+	StackBase int
 
 	// Optimizations
-	Prefix            string      // Literal prefix for fast searching
-	LookbehindLengths map[int]int // PC -> fixed length (0 = variable length)
+	Prefix string // Literal prefix for fast searching
+
+	// Where a match can start, for patterns without a prefix, see
+	// prefilter.go
+	prefilter *prefilter
+
+	// The program in the compact form the backtracker runs, see code.go
+	code    []code
+	classes [][]RuneRange
+	subs    []*Prog
+}
+
+// canSkip reports whether Input.Index can skip to where a match may start.
+func (prog *Prog) canSkip() bool {
+	return prog.Prefix != "" || prog.prefilter != nil
 }
 
 func (i Inst) String() string {
@@ -70,6 +104,30 @@ func (i Inst) String() string {
 		return fmt.Sprintf("look %v %d", i.LookNeg, i.Prog.Start)
 	case OpBackref:
 		return fmt.Sprintf("backref %d", i.Idx)
+	case OpRestorePos:
+		return fmt.Sprintf("restore %d", i.Idx)
+	case OpAtomic:
+		return fmt.Sprintf("atomic %d", i.Prog.Start)
+	case OpScriptRun:
+		return fmt.Sprintf("scriptrun %d", i.Idx)
+	case OpAbsent:
+		return fmt.Sprintf("absent %d", i.Prog.Start)
+	case OpPushCap:
+		return fmt.Sprintf("pushcap %d, %d", i.Idx, i.Arg)
+	case OpPopCap:
+		return fmt.Sprintf("popcap %d, %d", i.Idx, i.Arg)
+	case OpTransferCap:
+		return fmt.Sprintf("transfercap %d, %d", i.Idx, i.Arg)
+	case OpCond:
+		return fmt.Sprintf("cond %d, %d, %d", i.Idx, i.Out, i.Out1)
+	case OpCountReset:
+		return fmt.Sprintf("countreset %d", i.Idx)
+	case OpRepeat:
+		return fmt.Sprintf("repeat %d {%d,%d} %v, %d, %d", i.Idx, i.Min, i.Max, i.Greedy, i.Out, i.Out1)
+	case OpCountInc:
+		return fmt.Sprintf("countinc %d, %d", i.Idx, i.Out)
+	case OpProgress:
+		return fmt.Sprintf("progress %d, %d", i.Idx, i.Out)
 	}
 	return "?"
 }
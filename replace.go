@@ -1,6 +1,7 @@
 package gore
 
 import (
+	"context"
 	"strings"
 )
 
@@ -8,33 +9,24 @@ import (
 // Inside repl, $ signs are interpreted as in Expand, so for instance $1 represents
 // the text of the first submatch.
 func (re *Regexp) ReplaceAllString(src, repl string) string {
-	allMatches := re.FindAllStringSubmatch(src, -1)
-	if allMatches == nil {
-		return src
-	}
-
-	indices := re.FindAllStringIndex(src, -1)
-	if indices == nil || len(indices) != len(allMatches) {
-		return src
-	}
-
-	var result strings.Builder
-	lastEnd := 0
+	result, _ := re.ReplaceAllStringErr(src, repl)
+	return result
+}
 
-	for i, match := range allMatches {
-		// Append text before match
-		result.WriteString(src[lastEnd:indices[i][0]])
+// ReplaceAllStringErr is like ReplaceAllString but returns an error wrapping
+// ErrMatchLimit if the search for a match exceeds the limits. The result
+// then holds the replacements made so far, followed by the rest of src.
+func (re *Regexp) ReplaceAllStringErr(src, repl string) (string, error) {
+	return re.ReplaceAllStringContext(context.Background(), src, repl)
+}
 
+// ReplaceAllStringContext is like ReplaceAllStringErr but also stops,
+// returning ctx.Err(), once ctx is done.
+func (re *Regexp) ReplaceAllStringContext(ctx context.Context, src, repl string) (string, error) {
+	return re.replaceAll(ctx, src, true, func(caps []int) string {
 		// Expand template with captures from this match
-		expanded := re.expandStringWithCaptures(repl, match)
-		result.WriteString(expanded)
-
-		lastEnd = indices[i][1]
-	}
-
-	// Append remaining text
-	result.WriteString(src[lastEnd:])
-	return result.String()
+		return re.expandStringWithCaptures(repl, re.submatches(src, caps))
+	})
 }
 
 // ReplaceAllLiteralString replaces all matches with the replacement string literally
@@ -47,62 +39,35 @@ func (re *Regexp) ReplaceAllLiteralString(src, repl string) string {
 
 // ReplaceAllStringFunc replaces all matches using a function to generate replacement text.
 func (re *Regexp) ReplaceAllStringFunc(src string, repl func(string) string) string {
-	// Use FindAllStringSubmatchIndex to get capture positions
-	input := NewStringInput(src)
-	inputLen := input.Len()
-	pos := 0
+	result, _ := re.ReplaceAllStringFuncContext(context.Background(), src, repl)
+	return result
+}
 
+// ReplaceAllStringFuncContext is like ReplaceAllStringFunc but returns
+// ctx.Err() once ctx is done, or an error wrapping ErrMatchLimit or
+// ErrMatchTimeout if the search is stopped.
+func (re *Regexp) ReplaceAllStringFuncContext(ctx context.Context, src string, repl func(string) string) (string, error) {
+	return re.replaceAll(ctx, src, false, func(caps []int) string {
+		return repl(src[caps[0]:caps[1]])
+	})
+}
+
+// replaceAll replaces each match in src with the text repl returns for its
+// registers, which only include the submatches if submatches is set.
+func (re *Regexp) replaceAll(ctx context.Context, src string, submatches bool, repl func(caps []int) string) (string, error) {
 	var result strings.Builder
 	lastEnd := 0
 
-	for pos <= inputLen {
-		vm := NewVM(re.prog, input)
-
-		// Prefix optimization
-		if re.prog.Prefix != "" && pos < inputLen {
-			prefixPos := input.Index(re, pos)
-			if prefixPos == -1 {
-				break
-			}
-			pos = prefixPos
-		}
-
-		matched, caps := vm.Run(pos)
-		if matched && len(caps) >= 2 {
-			matchStart := caps[0]
-			matchEnd := caps[1]
-
-			// Append text before match
-			result.WriteString(src[lastEnd:matchStart])
-
-			// Apply replacement function
-			matchText := src[matchStart:matchEnd]
-			result.WriteString(repl(matchText))
-
-			lastEnd = matchEnd
-
-			// Advance past match (handle zero-width)
-			if matchEnd == pos {
-				_, w := input.Step(pos)
-				if w == 0 {
-					break
-				}
-				pos += w
-			} else {
-				pos = matchEnd
-			}
-		} else {
-			_, w := input.Step(pos)
-			if w == 0 {
-				break
-			}
-			pos += w
-		}
-	}
+	err := re.findAll(ctx, NewStringInput(src), -1, submatches, func(caps []int) {
+		// Append text before match
+		result.WriteString(src[lastEnd:caps[0]])
+		result.WriteString(repl(caps))
+		lastEnd = caps[1]
+	})
 
 	// Append remaining text
 	result.WriteString(src[lastEnd:])
-	return result.String()
+	return result.String(), err
 }
 
 // expandString expands template strings with $1, $2, $name substitutions.
@@ -280,10 +245,7 @@ func (re *Regexp) expandStringWithCaptures(template string, captures []string) s
 				}
 			} else {
 				// Named group
-				idx := re.SubexpIndex(name)
-				if idx >= 0 && idx < len(captures) && captures[idx] != "" {
-					expanded.WriteString(captures[idx])
-				}
+				expanded.WriteString(re.namedCapture(captures, name))
 			}
 			continue
 		}
@@ -305,10 +267,7 @@ func (re *Regexp) expandStringWithCaptures(template string, captures []string) s
 		}
 		if i > nameStart {
 			name := template[nameStart:i]
-			idx := re.SubexpIndex(name)
-			if idx >= 0 && idx < len(captures) && captures[idx] != "" {
-				expanded.WriteString(captures[idx])
-			}
+			expanded.WriteString(re.namedCapture(captures, name))
 			continue
 		}
 
@@ -335,3 +294,14 @@ func (re *Regexp) ReplaceAllFunc(src []byte, repl func([]byte) []byte) []byte {
 		return string(repl([]byte(s)))
 	}))
 }
+
+// namedCapture returns the text of the first group called name that took
+// part in the match, as several groups may share a name under (?J).
+func (re *Regexp) namedCapture(captures []string, name string) string {
+	for i, n := range re.subexpNames {
+		if n == name && i < len(captures) && captures[i] != "" {
+			return captures[i]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,157 @@
+package gore
+
+import (
+	"sort"
+	"sync"
+	"unicode"
+)
+
+// scriptRange maps a run of code points to a Unicode script name.
+type scriptRange struct {
+	Lo, Hi rune
+	Script string
+}
+
+var (
+	scriptTableOnce sync.Once
+	scriptTable     []scriptRange // Sorted by Lo, non-overlapping
+)
+
+// buildScriptTable flattens unicode.Scripts into a sorted range table.
+// Ranges with a stride are expanded so the table can be binary searched.
+func buildScriptTable() {
+	for name, tab := range unicode.Scripts {
+		for _, r := range tab.R16 {
+			for lo := rune(r.Lo); lo <= rune(r.Hi); lo += rune(r.Stride) {
+				hi := lo
+				if r.Stride == 1 {
+					hi = rune(r.Hi)
+				}
+				scriptTable = append(scriptTable, scriptRange{lo, hi, name})
+			}
+		}
+		for _, r := range tab.R32 {
+			for lo := rune(r.Lo); lo <= rune(r.Hi); lo += rune(r.Stride) {
+				hi := lo
+				if r.Stride == 1 {
+					hi = rune(r.Hi)
+				}
+				scriptTable = append(scriptTable, scriptRange{lo, hi, name})
+			}
+		}
+	}
+	sort.Slice(scriptTable, func(i, j int) bool {
+		return scriptTable[i].Lo < scriptTable[j].Lo
+	})
+}
+
+// scriptOf returns the Unicode script of r. Unassigned code points are
+// reported as "Unknown".
+func scriptOf(r rune) string {
+	// Fast path for ASCII
+	if r < 0x80 {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return "Latin"
+		}
+		return "Common"
+	}
+
+	scriptTableOnce.Do(buildScriptTable)
+	i := sort.Search(len(scriptTable), func(i int) bool {
+		return scriptTable[i].Hi >= r
+	})
+	if i < len(scriptTable) && scriptTable[i].Lo <= r {
+		return scriptTable[i].Script
+	}
+	return "Unknown"
+}
+
+// Han may be mixed with the scripts of the languages that use it.
+const (
+	cjkJapanese = 1 << iota // Han, Hiragana, Katakana
+	cjkKorean               // Han, Hangul
+	cjkChinese              // Han, Bopomofo
+)
+
+// cjkMask returns the CJK writing systems a script can belong to, or 0 if
+// the script is not part of one.
+func cjkMask(script string) int {
+	switch script {
+	case "Han":
+		return cjkJapanese | cjkKorean | cjkChinese
+	case "Hiragana", "Katakana":
+		return cjkJapanese
+	case "Hangul":
+		return cjkKorean
+	case "Bopomofo":
+		return cjkChinese
+	}
+	return 0
+}
+
+// digitZero returns the zero of the block of ten decimal digits containing r.
+// Unicode allocates each set of decimal digits as a contiguous 0..9 run, and
+// some runs are adjacent (the mathematical digits), so count from the start
+// of the whole run.
+func digitZero(r rune) rune {
+	start := r
+	for unicode.Is(unicode.Nd, start-1) {
+		start--
+	}
+	return start + (r-start)/10*10
+}
+
+// isScriptRun reports whether input[from:to] is a script run, following the
+// PCRE2 rules: every character must come from the same script, except that
+// Common and Inherited characters fit with any script, Han may be combined
+// with Hiragana and Katakana, Hangul, or Bopomofo, and all decimal digits
+// must come from the same block of ten.
+func isScriptRun(input Input, from, to int) bool {
+	script := ""     // The script of the run so far, if not CJK
+	cjk := 0         // Remaining CJK writing systems, if the run is CJK
+	zero := rune(-1) // Zero of the digits seen so far
+
+	for pos := from; pos < to; {
+		r, w := input.Step(pos)
+		if w == 0 {
+			break
+		}
+		pos += w
+
+		if unicode.Is(unicode.Nd, r) {
+			z := digitZero(r)
+			if zero == -1 {
+				zero = z
+			} else if z != zero {
+				return false
+			}
+		}
+
+		s := scriptOf(r)
+		if s == "Common" || s == "Inherited" {
+			continue
+		}
+
+		if m := cjkMask(s); m != 0 {
+			if script != "" {
+				return false
+			}
+			if cjk == 0 {
+				cjk = m
+			} else if cjk &= m; cjk == 0 {
+				return false
+			}
+			continue
+		}
+
+		if cjk != 0 {
+			return false
+		}
+		if script == "" {
+			script = s
+		} else if s != script {
+			return false
+		}
+	}
+	return true
+}
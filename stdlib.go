@@ -0,0 +1,267 @@
+package gore
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Patterns that only use what RE2 supports, with the same meaning, are
+// handed to the standard library's regexp, whose engines are tuned far
+// beyond gore's for that subset. The pattern is not passed on as written:
+// the AST is printed back as RE2 syntax, with every class spelled out as
+// ranges, so that differences such as \s including \v in gore do not
+// matter.
+//
+// Two differences remain, and the calls they affect stay on gore's engines:
+//   - gore's $ and \z also match before a NUL, so calls on input with a NUL
+//     are not handed over if the pattern has either.
+//   - After a match, gore's FindAll allows an empty match where it ended and
+//     Go's does not, so repeated searches with a pattern that can match the
+//     empty string are not handed over.
+// Searches on an io.Reader are never handed over either.
+
+// stdlib is what a Regexp needs to hand calls to the standard library.
+type stdlib struct {
+	re      *regexp.Regexp
+	nul     bool // The pattern has $ or \z
+	empty   bool // The pattern can match the empty string
+	numRegs int
+}
+
+// compileStdlib returns node compiled by the standard library, or nil if
+// it uses something RE2 lacks or does differently.
+func compileStdlib(node Node, prog *Prog) *stdlib {
+	p := &stdPrinter{}
+	if !p.print(node) {
+		return nil
+	}
+	re, err := regexp.Compile(p.String())
+	if err != nil || re.NumSubexp() != prog.NumCap-1 {
+		return nil
+	}
+	return &stdlib{re: re, nul: p.nul, empty: canMatchEmpty(node), numRegs: prog.NumRegs}
+}
+
+// stdPrinter prints an AST as RE2 syntax.
+type stdPrinter struct {
+	strings.Builder
+	captures int  // Groups printed so far
+	nul      bool // $ or \z printed
+}
+
+// print writes node, reporting false if RE2 cannot express it.
+func (p *stdPrinter) print(node Node) bool {
+	switch n := node.(type) {
+	case *Literal:
+		if n.FoldCase {
+			p.WriteString("(?i:")
+		} else {
+			p.WriteString("(?:")
+		}
+		for _, r := range n.Runes {
+			p.WriteString(regexp.QuoteMeta(string(r)))
+		}
+		p.WriteString(")")
+
+	case *CharClass:
+		p.printClass(n)
+
+	case *Concat:
+		p.WriteString("(?:")
+		for _, sub := range n.Nodes {
+			if !p.print(sub) {
+				return false
+			}
+		}
+		p.WriteString(")")
+
+	case *Alternate:
+		p.WriteString("(?:")
+		for i, sub := range n.Nodes {
+			if i > 0 {
+				p.WriteString("|")
+			}
+			if !p.print(sub) {
+				return false
+			}
+		}
+		p.WriteString(")")
+
+	case *Quantifier:
+		// Loops whose body matches empty capture differently in Go
+		if n.Max != 0 && n.Max != 1 && canMatchEmpty(n.Body) {
+			return false
+		}
+		p.WriteString("(?:")
+		if !p.print(n.Body) {
+			return false
+		}
+		p.WriteString(")")
+		if n.Max < 0 {
+			fmt.Fprintf(p, "{%d,}", n.Min)
+		} else {
+			fmt.Fprintf(p, "{%d,%d}", n.Min, n.Max)
+		}
+		if !n.Greedy {
+			p.WriteString("?")
+		}
+
+	case *Capture:
+		// Go numbers groups in the order they open, so gore must too
+		if n.Stacked || n.Index != p.captures+1 {
+			return false
+		}
+		p.captures++
+		p.WriteString("(")
+		if !p.print(n.Body) {
+			return false
+		}
+		p.WriteString(")")
+
+	case *Assertion:
+		switch {
+		case n.Kind == AssertStartText && n.Multiline:
+			p.WriteString("(?m:^)")
+		case n.Kind == AssertStartText, n.Kind == AssertStringStart:
+			p.WriteString(`\A`)
+		case n.Kind == AssertEndText && n.Multiline:
+			p.WriteString("(?m:$)")
+			p.nul = true
+		case n.Kind == AssertEndText, n.Kind == AssertAbsoluteEnd:
+			p.WriteString(`\z`)
+			p.nul = true
+		case n.Kind == AssertWordBoundary && !n.Unicode:
+			p.WriteString(`\b`)
+		case n.Kind == AssertNotWordBoundary && !n.Unicode:
+			p.WriteString(`\B`)
+		default:
+			return false // \Z, and \b under (*UCP)
+		}
+
+	default:
+		return false
+	}
+	return true
+}
+
+// printClass writes a class as explicit ranges.
+func (p *stdPrinter) printClass(n *CharClass) {
+	if n.FoldCase {
+		p.WriteString("(?i:")
+	}
+	switch {
+	case len(n.Ranges) == 0 && n.Negated:
+		p.WriteString(`[\x{0}-\x{10FFFF}]`)
+	case len(n.Ranges) == 0:
+		p.WriteString(`[^\x{0}-\x{10FFFF}]`)
+	default:
+		p.WriteString("[")
+		if n.Negated {
+			p.WriteString("^")
+		}
+		for _, rng := range n.Ranges {
+			fmt.Fprintf(p, `\x{%x}-\x{%x}`, rng.Lo, rng.Hi)
+		}
+		p.WriteString("]")
+	}
+	if n.FoldCase {
+		p.WriteString(")")
+	}
+}
+
+// canMatchEmpty reports whether node can match the empty string. It errs
+// on the side of true for the constructs it does not look into.
+func canMatchEmpty(node Node) bool {
+	switch n := node.(type) {
+	case *Literal:
+		return len(n.Runes) == 0
+	case *CharClass:
+		return false
+	case *Concat:
+		for _, sub := range n.Nodes {
+			if !canMatchEmpty(sub) {
+				return false
+			}
+		}
+		return true
+	case *Alternate:
+		for _, sub := range n.Nodes {
+			if canMatchEmpty(sub) {
+				return true
+			}
+		}
+		return len(n.Nodes) == 0
+	case *Quantifier:
+		return n.Min == 0 || canMatchEmpty(n.Body)
+	case *Capture:
+		return canMatchEmpty(n.Body)
+	}
+	return true
+}
+
+// stdInput returns the text of input if calls on it can be handed to the
+// standard library.
+func (re *Regexp) stdInput(input Input) (string, bool) {
+	if re.std == nil {
+		return "", false
+	}
+	s, ok := input.(*StringInput)
+	if !ok || re.std.nul && strings.IndexByte(s.str, 0) >= 0 {
+		return "", false
+	}
+	return s.str, true
+}
+
+// findStd is find for a search from the start of s on the standard
+// library. The library cannot be interrupted, so the context is only
+// checked before it starts.
+func (re *Regexp) findStd(vm *VM, s string, submatches bool) ([]int, error) {
+	if vm.ctx != nil {
+		if err := vm.ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	var loc []int
+	if submatches {
+		loc = re.std.re.FindStringSubmatchIndex(s)
+	} else {
+		loc = re.std.re.FindStringIndex(s)
+	}
+	if loc == nil {
+		return nil, nil
+	}
+	return vm.setCaps(re.std.numRegs, loc), nil
+}
+
+// findAllStd is findAll on the standard library.
+func (re *Regexp) findAllStd(vm *VM, s string, n int, submatches bool, deliver func(caps []int)) error {
+	if vm.ctx != nil {
+		if err := vm.ctx.Err(); err != nil {
+			return err
+		}
+	}
+	var locs [][]int
+	if submatches {
+		locs = re.std.re.FindAllStringSubmatchIndex(s, n)
+	} else {
+		locs = re.std.re.FindAllStringIndex(s, n)
+	}
+	for _, loc := range locs {
+		deliver(vm.setCaps(re.std.numRegs, loc))
+	}
+	return nil
+}
+
+// setCaps returns vm.caps holding loc, with the registers after it unset.
+func (vm *VM) setCaps(n int, loc []int) []int {
+	if cap(vm.caps) < n {
+		vm.caps = make([]int, n)
+	}
+	vm.caps = vm.caps[:n]
+	copy(vm.caps, loc)
+	for i := len(loc); i < n; i++ {
+		vm.caps[i] = -1
+	}
+	return vm.caps
+}
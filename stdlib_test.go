@@ -0,0 +1,116 @@
+package gore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestStdlibSelection tests which patterns are handed to the standard
+// library
+func TestStdlibSelection(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{`abc`, true},
+		{`(\w+)\s*=\s*(\d+)`, true},
+		{`(?i)^(get|post) /`, true},
+		{`(?m)^\s*#.*$`, true},
+		{`a{2,100}?`, true},
+		{`(?<year>\d{4})-(?<month>\d\d)`, true},
+		{`(a)\1`, false},              // Backreference
+		{`a(?=b)`, false},             // Lookaround
+		{`abc\Z`, false},              // No \Z in RE2
+		{`(*UCP)\bé`, false},          // No Unicode \b in RE2
+		{`(a*)+b`, false},             // Go captures empty iterations differently
+		{`a{1001}`, false},            // Beyond RE2's repeat limit
+		{`(?J)(?<n>a)|(?<n>b)`, true}, // Groups keep their own numbers
+	}
+	for _, tc := range tests {
+		re := MustCompile(tc.pattern)
+		if got := re.Engine() == EngineStdlib; got != tc.want {
+			t.Errorf("Compile(%q).Engine() = %v; want stdlib = %v", tc.pattern, re.Engine(), tc.want)
+		}
+	}
+
+	if got := MustCompileWithOptions(`abc`, Options{NoStdlib: true}).Engine(); got == EngineStdlib {
+		t.Errorf("NoStdlib: Engine() = %v", got)
+	}
+	if got := MustCompileWithOptions(`abc`, Options{Engine: EngineStdlib}).Engine(); got != EngineStdlib {
+		t.Errorf("forced stdlib: Engine() = %v", got)
+	}
+	var err *Error
+	if _, compileErr := CompileWithOptions(`(a)\1`, Options{Engine: EngineStdlib}); !errors.As(compileErr, &err) || err.Code != ErrUnsupported {
+		t.Errorf("forced stdlib on a backreference: err = %v; want ErrUnsupported", compileErr)
+	}
+}
+
+// TestStdlibMatchesGore tests that handing a pattern to the standard library
+// does not change any result
+func TestStdlibMatchesGore(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+	}{
+		{`(\w+)\s*=\s*(\w*)`, "key = value; k2=; x =y"},
+		{`\s+`, "a\vb\tc  d"},
+		{`a$`, "a\x00a"},
+		{`(?m)^\w+$`, "one\x00\ntwo\n"},
+		{`a*`, "baaac"},
+		{`x*`, "axxbx"},
+		{`(?i)k+`, "kKKx"},
+		{`(?i)[^a-z]+`, "abc123DEF"},
+		{`.+`, "ab\ncd"},
+		{`(?s).+`, "ab\ncd"},
+		{`\bfoo\b`, "foo food afoo foo"},
+		{`[^\d]`, "\xff1a"},
+		{`(a|ab)(c|bcd)(d*)`, "abcd"},
+		{`(?U)(a+)(a+?)`, "aaaa"},
+		{`(?n)(a)(?<b>b)`, "ab"},
+		{`é+|(ü)`, "café ü éé"},
+		{``, "abc"},
+	}
+	for _, tc := range tests {
+		std := MustCompile(tc.pattern)
+		if std.Engine() != EngineStdlib {
+			t.Errorf("%s: runs on %v; want stdlib", tc.pattern, std.Engine())
+			continue
+		}
+		own := MustCompileWithOptions(tc.pattern, Options{NoStdlib: true})
+
+		if got, want := std.MatchString(tc.input), own.MatchString(tc.input); got != want {
+			t.Errorf("MatchString(%q, %q) = %v; gore gives %v", tc.pattern, tc.input, got, want)
+		}
+		if got, want := std.FindStringSubmatch(tc.input), own.FindStringSubmatch(tc.input); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindStringSubmatch(%q, %q) = %q; gore gives %q", tc.pattern, tc.input, got, want)
+		}
+		if got, want := std.FindAllStringSubmatch(tc.input, -1), own.FindAllStringSubmatch(tc.input, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("FindAllStringSubmatch(%q, %q) = %q; gore gives %q", tc.pattern, tc.input, got, want)
+		}
+		if got, want := std.ReplaceAllString(tc.input, "<$0>"), own.ReplaceAllString(tc.input, "<$0>"); got != want {
+			t.Errorf("ReplaceAllString(%q, %q) = %q; gore gives %q", tc.pattern, tc.input, got, want)
+		}
+		if got, want := std.Split(tc.input, -1), own.Split(tc.input, -1); !reflect.DeepEqual(got, want) {
+			t.Errorf("Split(%q, %q) = %q; gore gives %q", tc.pattern, tc.input, got, want)
+		}
+	}
+}
+
+// TestStdlibContext tests that a call handed to the standard library still
+// returns the context's error if it is already done
+func TestStdlibContext(t *testing.T) {
+	re := MustCompile(`(a|b)*c`)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := re.MatchStringContext(canceled, "abc"); !errors.Is(err, context.Canceled) {
+		t.Errorf("MatchStringContext: err = %v; want context.Canceled", err)
+	}
+	if _, err := re.FindAllStringIndexContext(canceled, "abc", -1); !errors.Is(err, context.Canceled) {
+		t.Errorf("FindAllStringIndexContext: err = %v; want context.Canceled", err)
+	}
+	if m, err := re.FindStringContext(context.Background(), "xabc"); m != "abc" || err != nil {
+		t.Errorf("FindStringContext = %q, %v; want \"abc\", nil", m, err)
+	}
+}
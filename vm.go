@@ -1,92 +1,276 @@
 package gore
 
 import (
-	"sync"
+	"context"
+	"fmt"
+	"sort"
+	"time"
 	"unicode"
+	"unsafe"
 )
 
-// Pool for capture slice allocations to reduce GC pressure
-var capsPool = sync.Pool{
-	New: func() any {
-		// Pre-allocate reasonable size
-		// Return a pointer to slice to avoid allocation when putting into interface{}
-		s := make([]int, 0, 20)
-		return &s
-	},
-}
-
 // VM executes the regex program.
 type VM struct {
 	prog  *Prog
 	input Input
+
+	// Character matching never crosses lo (backwards) or hi (forwards).
+	lo, hi int
+
+	// Capture stacks for balancing groups. Records are never modified once
+	// pushed, and each group's stack register points at its newest record,
+	// so restoring registers on backtracking also restores the stacks.
+	capStack []capRecord
+	root     *VM // VM owning capStack and the backtrack stack, for subprograms
+
+	// Alternatives to resume from when the current path fails, newest last.
+	// trail logs the old value of every register written while there are
+	// alternatives, so that resuming one rolls the registers back to how
+	// they were when it was pushed. Subprograms share the root's stack and
+	// trail above the entries of the VM that called them. While calls is
+	// non-zero every write is logged, so that a subprogram's writes can be
+	// undone even with no alternatives pending; see call.
+	stack []backtrack
+	trail []undo
+	calls int
+
+	caps []int         // Registers returned by Run, reused by the next call
+	subs map[*Prog]*VM // VMs for subprograms, see sub
+	ends []int         // Candidate end points, for the OpAbsent that runs this VM
+	pike *pikeState    // Working memory of the Pike VM during a search
+	alt  []int         // Registers of the match the one-pass engine falls back to
+
+	// States the search has already visited, if memoization is on; see
+	// memo.go. Only the root VM has a plan.
+	memoPlan *memoPlan
+	memo     memoTable
+
+	// Work done by the current search against its limits, counted on the
+	// root VM. A zero limit means none. err is set once a limit is hit, and
+	// every frame then fails without doing more work.
+	limits            Limits
+	steps, backtracks int
+	err               error
+
+	// The call can also be stopped by its context or its timeout, which
+	// are checked every interruptInterval steps.
+	ctx      context.Context // nil if it cannot be canceled
+	deadline time.Time       // zero if there is no timeout
+	timeout  time.Duration
+}
+
+// interruptInterval is how many steps the VM runs between checks for
+// cancellation. It must be a power of two.
+const interruptInterval = 1024
+
+// backtrack is a point the VM can resume from: instruction pc at pos, once
+// the trail has been rolled back to its first trail entries.
+type backtrack struct {
+	pc, pos int
+	trail   int
+}
+
+// undo is a register write logged on the trail, with the value it replaced.
+type undo struct {
+	reg, old int
+}
+
+// Sizes used to measure the backtrack stack against Limits.StackLimit.
+const (
+	backtrackSize = int(unsafe.Sizeof(backtrack{}))
+	undoSize      = int(unsafe.Sizeof(undo{}))
+)
+
+// capRecord is one entry on a group's capture stack.
+type capRecord struct {
+	start, end int
+	prev       int // Index of the record below, -1 at the bottom
 }
 
 func NewVM(prog *Prog, input Input) *VM {
-	return &VM{prog: prog, input: input}
+	return &VM{prog: prog, input: input, hi: input.Len()}
+}
+
+// sub returns a VM for running a subprogram against the same input and
+// capture stacks. The root keeps one per subprogram for reuse.
+func (vm *VM) sub(prog *Prog) *VM {
+	root := vm.stackOwner()
+	s := root.subs[prog]
+	if s == nil {
+		if root.subs == nil {
+			root.subs = make(map[*Prog]*VM)
+		}
+		s = &VM{prog: prog, input: vm.input, root: root}
+		root.subs[prog] = s
+	}
+	s.lo, s.hi = 0, vm.input.Len()
+	return s
+}
+
+// resetBudget starts counting work against the limits afresh.
+func (vm *VM) resetBudget() {
+	vm.steps, vm.backtracks = 0, 0
+	vm.err = nil
+}
+
+// checkInterrupt sets vm.err if the call has been canceled or has run out
+// of time, and reports whether it has.
+func (vm *VM) checkInterrupt() bool {
+	if vm.ctx != nil {
+		select {
+		case <-vm.ctx.Done():
+			vm.err = vm.ctx.Err()
+			return true
+		default:
+		}
+	}
+	if !vm.deadline.IsZero() && time.Now().After(vm.deadline) {
+		vm.err = fmt.Errorf("%w after %v", ErrMatchTimeout, vm.timeout)
+		return true
+	}
+	return false
 }
 
 // Run executes the VM starting at the given position.
-// Returns true if match found, and the capture positions.
+// Returns true if match found, and the capture positions. The registers
+// belong to the VM and are only valid until the next call to Run.
 func (vm *VM) Run(pos int) (bool, []int) {
-	// Get caps from pool and ensure proper size
-	poolCapsPtr := capsPool.Get().(*[]int)
-	caps := (*poolCapsPtr)[:0] // Reset length
-
-	// Ensure capacity
-	needed := vm.prog.NumCap * 2
-	if cap(caps) < needed {
-		caps = make([]int, needed)
-	} else {
-		caps = caps[:needed]
+	needed := vm.prog.NumRegs
+	if cap(vm.caps) < needed {
+		vm.caps = make([]int, needed)
 	}
+	caps := vm.caps[:needed]
 
 	// Initialize to -1
 	for i := range caps {
 		caps[i] = -1
 	}
+	vm.capStack = vm.capStack[:0]
+	vm.stack, vm.trail = vm.stack[:0], vm.trail[:0]
 
-	endPos, matched := vm.match(vm.prog.Start, pos, caps)
-	if matched {
-		// Return actual caps, don't put back in pool since caller uses them
-		// endPos not used here but needed for match signature consistency
-		_ = endPos
-		return true, caps
+	if _, matched := vm.match(vm.prog.Start, pos, caps); !matched {
+		return false, nil
 	}
+	return true, caps
+}
 
-	// Return caps to pool
-	// Return caps to pool
-	// Update pointer to point to potentially new slice (if realloc)
-	*poolCapsPtr = caps
-	capsPool.Put(poolCapsPtr)
-	return false, nil
+// call runs the subprogram VM sub from pos on caps, as match does, and
+// returns where it ended, whether it matched, and how long the trail was
+// before it ran. Its register writes are all logged: if it matches they stay
+// in caps, for backtracking to undo, or for the caller to undo with
+// rollback; if it fails they have already been undone.
+func (vm *VM) call(sub *VM, pos int, caps []int) (int, bool, int) {
+	root := vm.stackOwner()
+	mark := len(root.trail)
+	root.calls++
+	endPos, matched := sub.match(sub.prog.Start, pos, caps)
+	root.calls--
+	return endPos, matched, mark
 }
 
-// match is the unified backtracking function.
-// Returns (endPos, matched) where endPos is the position after match.
+// rollback undoes the register writes logged on the trail after its first
+// mark entries.
+func (vm *VM) rollback(caps []int, mark int) {
+	root := vm.stackOwner()
+	for i := len(root.trail) - 1; i >= mark; i-- {
+		caps[root.trail[i].reg] = root.trail[i].old
+	}
+	root.trail = root.trail[:mark]
+}
+
+// match runs the program from pc at pos, backtracking until a path reaches
+// OpMatch or every alternative has failed. Returns (endPos, matched) where
+// endPos is the position after the match. Alternatives left on the stack are
+// dropped once it returns, so a subprogram only ever matches one way, but
+// the writes of the way it matched stay on the trail.
 func (vm *VM) match(pc int, pos int, caps []int) (int, bool) {
-	// Iteration limit to prevent infinite loops
-	const maxSteps = 1000000
-	steps := 0
+	root := vm.stackOwner()
+	base, trailBase := len(root.stack), len(root.trail)
+	for {
+		if endPos, ok := vm.run(pc, pos, caps); ok {
+			root.stack = root.stack[:base]
+			return endPos, true
+		}
+		if root.err != nil || len(root.stack) == base {
+			root.stack = root.stack[:base]
+			vm.rollback(caps, trailBase)
+			return -1, false
+		}
+
+		if root.backtracks++; root.limits.BacktrackLimit > 0 && root.backtracks > root.limits.BacktrackLimit {
+			root.err = fmt.Errorf("%w: more than %d backtracks", ErrMatchLimit, root.limits.BacktrackLimit)
+			continue
+		}
+
+		// Resume from the newest alternative, undoing the register writes
+		// made since it was pushed
+		bt := root.stack[len(root.stack)-1]
+		root.stack = root.stack[:len(root.stack)-1]
+		vm.rollback(caps, bt.trail)
+		pc, pos = bt.pc, bt.pos
+	}
+}
+
+// push records an alternative to resume from at pc and pos with the
+// registers as they are now. It fails, setting root.err, if the stack would
+// grow past the limits.
+func (vm *VM) push(pc, pos int) bool {
+	root := vm.stackOwner()
+	if root.limits.DepthLimit > 0 && len(root.stack) >= root.limits.DepthLimit {
+		root.err = fmt.Errorf("%w: more than %d nested backtracking points", ErrMatchLimit, root.limits.DepthLimit)
+		return false
+	}
+	if root.limits.StackLimit > 0 &&
+		(len(root.stack)+1)*backtrackSize+len(root.trail)*undoSize > root.limits.StackLimit {
+		root.err = fmt.Errorf("%w: backtrack stack exceeds %d bytes", ErrMatchLimit, root.limits.StackLimit)
+		return false
+	}
+	root.stack = append(root.stack, backtrack{pc: pc, pos: pos, trail: len(root.trail)})
+	return true
+}
+
+// set writes val to register reg, logging the old value on the trail if an
+// alternative or a call may need it back.
+func (vm *VM) set(caps []int, reg, val int) {
+	if root := vm.stackOwner(); (len(root.stack) > 0 || root.calls > 0) && caps[reg] != val {
+		root.trail = append(root.trail, undo{reg: reg, old: caps[reg]})
+	}
+	caps[reg] = val
+}
 
+// run follows one path through the program from pc at pos, pushing the
+// alternatives it passes, until it reaches OpMatch or fails.
+func (vm *VM) run(pc int, pos int, caps []int) (int, bool) {
+	root := vm.stackOwner()
+	insts := vm.prog.code
 	for {
-		steps++
-		if steps > maxSteps || pc >= len(vm.prog.Insts) {
+		if root.err != nil || pc >= len(insts) {
+			return -1, false
+		}
+		if root.steps++; root.limits.MatchLimit > 0 && root.steps > root.limits.MatchLimit {
+			root.err = fmt.Errorf("%w: more than %d steps", ErrMatchLimit, root.limits.MatchLimit)
+			return -1, false
+		}
+		if root.steps&(interruptInterval-1) == 0 && (root.ctx != nil || !root.deadline.IsZero()) && root.checkInterrupt() {
+			return -1, false
+		}
+		if vm.memoPlan != nil && !vm.visit(pc, pos) {
 			return -1, false
 		}
 
-		inst := vm.prog.Insts[pc]
+		inst := &insts[pc]
 
-		switch inst.Op {
+		switch OpCode(inst.op) {
 		case OpMatch:
 			return pos, true
 
 		case OpChar:
-			r, w := vm.input.Step(pos)
+			r, w := vm.step(pos, inst.flags&codeReverse != 0)
 			matched := false
-			if inst.FoldCase {
-				matched = simpleFoldEqual(r, inst.Val)
+			if inst.flags&codeFoldCase != 0 {
+				matched = simpleFoldEqual(r, rune(inst.val))
 			} else {
-				matched = r == inst.Val
+				matched = r == rune(inst.val)
 			}
 			if !matched {
 				return -1, false
@@ -95,18 +279,18 @@ func (vm *VM) match(pc int, pos int, caps []int) (int, bool) {
 			pc++
 
 		case OpCharClass:
-			r, w := vm.input.Step(pos)
+			r, w := vm.step(pos, inst.flags&codeReverse != 0)
 			if w == 0 { // EOF
 				return -1, false
 			}
-			if !matchClass(r, inst.Ranges, inst.Negated, inst.FoldCase) {
+			if !matchClass(r, vm.prog.classes[inst.val], inst.flags&codeNegated != 0, inst.flags&codeFoldCase != 0) {
 				return -1, false
 			}
 			pos += w
 			pc++
 
 		case OpAny:
-			r, w := vm.input.Step(pos)
+			r, w := vm.step(pos, inst.flags&codeReverse != 0)
 			if w == 0 { // EOF
 				return -1, false
 			}
@@ -117,78 +301,32 @@ func (vm *VM) match(pc int, pos int, caps []int) (int, bool) {
 			pc++
 
 		case OpJmp:
-			pc = inst.Out
+			pc = int(inst.out)
 
 		case OpSplit:
-			// Backtracking split: try both branches
-			// Get caps copy from pool
-			poolCapsPtr := capsPool.Get().(*[]int)
-			capsCopy := (*poolCapsPtr)[:0]
-			if cap(capsCopy) < len(caps) {
-				capsCopy = make([]int, len(caps))
-			} else {
-				capsCopy = capsCopy[:len(caps)]
-			}
-			copy(capsCopy, caps)
-
-			// Try first branch
-			if endPos, ok := vm.match(inst.Out, pos, capsCopy); ok {
-				copy(caps, capsCopy)
-				*poolCapsPtr = capsCopy
-				capsPool.Put(poolCapsPtr)
-				return endPos, true
+			// Try the first branch, coming back to the second if it fails
+			if !vm.push(int(inst.out1), pos) {
+				return -1, false
 			}
-
-			// Return copy to pool
-			// Return copy to pool
-			*poolCapsPtr = capsCopy
-			capsPool.Put(poolCapsPtr)
-
-			// Try second branch (tail call optimization possible)
-			return vm.match(inst.Out1, pos, caps)
+			pc = int(inst.out)
 
 		case OpSave:
-			caps[inst.Idx] = pos
+			vm.set(caps, int(inst.idx), pos)
 			pc++
 
 		case OpAssert:
-			if !vm.checkAssertion(inst.Assert, pos, inst.Multiline) {
+			if !vm.checkAssertion(AssertionType(inst.val), pos, inst.flags&codeMultiline != 0, inst.flags&codeUnicode != 0) {
 				return -1, false
 			}
 			pc++
 
 		case OpLookaround:
-			subVM := NewVM(inst.Prog, vm.input)
-			matched := false
-
-			if inst.LookBehind {
-				// Check if this is a fixed-length lookbehind
-				fixedLen, exists := vm.prog.LookbehindLengths[pc]
-
-				if exists && fixedLen > 0 {
-					// Optimized: fixed-length lookbehind O(1)
-					// Only try matching from the exact position
-					startPos := pos - fixedLen
-					if startPos >= 0 {
-						if endPos, ok := subVM.match(subVM.prog.Start, startPos, make([]int, subVM.prog.NumCap*2)); ok && endPos == pos {
-							matched = true
-						}
-					}
-				} else {
-					// Fallback: O(pos) scan for variable-length lookbehind
-					for i := 0; i <= pos; i++ {
-						if endPos, ok := subVM.match(subVM.prog.Start, i, make([]int, subVM.prog.NumCap*2)); ok && endPos == pos {
-							matched = true
-							break
-						}
-					}
-				}
-			} else {
-				// Lookahead
-				_, matched = subVM.match(subVM.prog.Start, pos, make([]int, subVM.prog.NumCap*2))
-			}
+			// Positive assertions keep whatever they captured. A negative one
+			// only succeeds if the body failed, which undid its writes.
+			// Lookbehind bodies are reverse programs that run backwards from pos.
+			_, matched, _ := vm.call(vm.sub(vm.prog.subs[inst.val]), pos, caps)
 
-			if inst.LookNeg {
+			if inst.flags&codeLookNeg != 0 {
 				if matched {
 					return -1, false
 				}
@@ -201,7 +339,7 @@ func (vm *VM) match(pc int, pos int, caps []int) (int, bool) {
 
 		case OpBackref:
 			// Get the capture group index (1-based in AST, but we store as 1-based)
-			capIdx := inst.Idx
+			capIdx := int(inst.idx)
 			// Captures are stored as pairs: [start0, end0, start1, end1, ...]
 			// Group 0 is the whole match, group 1 is at indices 2,3, etc.
 			startIdx := capIdx * 2
@@ -228,11 +366,19 @@ func (vm *VM) match(pc int, pos int, caps []int) (int, bool) {
 				continue
 			}
 
-			// Match the captured text at the current position
+			// Match the captured text at the current position. Backwards, the
+			// text must end at the current position instead.
 			capLen := capEnd - capStart
+			from := pos
+			if inst.flags&codeReverse != 0 {
+				from = pos - capLen
+				if from < 0 {
+					return -1, false
+				}
+			}
 			for i := 0; i < capLen; i++ {
 				r1, w1 := vm.input.Step(capStart + i)
-				r2, w2 := vm.input.Step(pos + i)
+				r2, w2 := vm.input.Step(from + i)
 
 				// Check EOF
 				if w1 == 0 || w2 == 0 {
@@ -246,10 +392,208 @@ func (vm *VM) match(pc int, pos int, caps []int) (int, bool) {
 			}
 
 			// Advance position by the length of the matched backreference
-			pos += capLen
+			if inst.flags&codeReverse != 0 {
+				pos -= capLen
+			} else {
+				pos += capLen
+			}
+			pc++
+
+		case OpRestorePos:
+			pos = caps[int(inst.idx)]
+			pc++
+
+		case OpAtomic:
+			// Like a positive lookahead, except that the match consumes input.
+			// Only the first way the body matches is ever tried.
+			endPos, matched, _ := vm.call(vm.sub(vm.prog.subs[inst.val]), pos, caps)
+			if !matched {
+				return -1, false
+			}
+			pos = endPos
+			pc++
+
+		case OpAbsent:
+			// Collect every point the operator can stretch to, moving away
+			// from pos until the text covered would contain a match of the
+			// body. The body runs the other way from each candidate point
+			// and may not cross pos.
+			subVM := vm.sub(vm.prog.subs[inst.val])
+			if inst.flags&codeReverse != 0 {
+				subVM.hi = pos
+			} else {
+				subVM.lo = pos
+			}
+
+			// The candidates are kept on the subprogram's VM for reuse
+			ends := subVM.ends[:0]
+			for end := pos; ; {
+				if _, found, mark := vm.call(subVM, end, caps); found {
+					vm.rollback(caps, mark)
+					break
+				}
+				ends = append(ends, end)
+				_, w := vm.step(end, inst.flags&codeReverse != 0)
+				if w == 0 {
+					break
+				}
+				end += w
+			}
+			subVM.ends = ends
+
+			// Try the longest candidate first, like a greedy quantifier
+			if len(ends) == 0 {
+				return -1, false
+			}
+			for _, end := range ends[:len(ends)-1] {
+				if !vm.push(pc+1, end) {
+					return -1, false
+				}
+			}
+			pos = ends[len(ends)-1]
+			pc++
+
+		case OpPushCap:
+			start, end := caps[inst.arg], pos
+			if start > end { // Matched backwards
+				start, end = end, start
+			}
+			vm.pushCap(caps, int(inst.idx), start, end)
+			pc++
+
+		case OpPopCap:
+			root := vm.stackOwner()
+			top := caps[vm.prog.StackBase+int(inst.idx)]
+			if top == -1 {
+				return -1, false // Nothing to balance
+			}
+			rec := root.capStack[top]
+			vm.set(caps, inst.arg+1, rec.start)
+			vm.set(caps, inst.arg+2, rec.end)
+
+			// The group now shows its previous capture, if any
+			vm.set(caps, vm.prog.StackBase+int(inst.idx), rec.prev)
+			if rec.prev == -1 {
+				vm.set(caps, 2*int(inst.idx), -1)
+				vm.set(caps, 2*int(inst.idx)+1, -1)
+			} else {
+				prev := root.capStack[rec.prev]
+				vm.set(caps, 2*int(inst.idx), prev.start)
+				vm.set(caps, 2*int(inst.idx)+1, prev.end)
+			}
+			pc++
+
+		case OpTransferCap:
+			// The new capture is the text between the popped capture and
+			// this group, as in .NET.
+			start, end := caps[inst.arg], pos
+			if start > end {
+				start, end = end, start
+			}
+			popStart, popEnd := caps[inst.arg+1], caps[inst.arg+2]
+			switch {
+			case start >= popEnd:
+				start, end = popEnd, start
+			case end <= popStart:
+				start, end = end, popStart
+			default:
+				start, end = max(start, popStart), min(end, popEnd)
+			}
+			vm.pushCap(caps, int(inst.idx), start, end)
+			pc++
+
+		case OpCond:
+			if 2*int(inst.idx)+1 < len(caps) && caps[2*int(inst.idx)+1] != -1 {
+				pc = int(inst.out)
+			} else {
+				pc = int(inst.out1)
+			}
+
+		case OpCountReset:
+			vm.set(caps, int(inst.idx), 0)
+			pc++
+
+		case OpRepeat:
+			// Out is the body and Out1 the exit; the register counts the
+			// repetitions done so far
+			n := caps[int(inst.idx)]
+			switch {
+			case n < inst.arg:
+				pc = int(inst.out)
+			case inst.val >= 0 && n >= inst.val:
+				pc = int(inst.out1)
+			case inst.flags&codeGreedy != 0:
+				if !vm.push(int(inst.out1), pos) {
+					return -1, false
+				}
+				pc = int(inst.out)
+			default:
+				if !vm.push(int(inst.out), pos) {
+					return -1, false
+				}
+				pc = int(inst.out1)
+			}
+
+		case OpCountInc:
+			vm.set(caps, int(inst.idx), caps[int(inst.idx)]+1)
+			pc = int(inst.out)
+
+		case OpProgress:
+			// An iteration that matched empty leaves the loop
+			if caps[int(inst.idx)] == pos {
+				pc++
+			} else {
+				pc = int(inst.out)
+			}
+
+		case OpScriptRun:
+			// The body may have run backwards inside a lookbehind
+			from, to := caps[int(inst.idx)], pos
+			if from > to {
+				from, to = to, from
+			}
+			if !isScriptRun(vm.input, from, to) {
+				return -1, false
+			}
 			pc++
+
+		}
+	}
+}
+
+// stackOwner returns the VM holding the capture stacks.
+func (vm *VM) stackOwner() *VM {
+	if vm.root != nil {
+		return vm.root
+	}
+	return vm
+}
+
+// pushCap records a capture of group idx and makes it the group's value.
+func (vm *VM) pushCap(caps []int, idx, start, end int) {
+	root := vm.stackOwner()
+	reg := vm.prog.StackBase + idx
+	root.capStack = append(root.capStack, capRecord{start: start, end: end, prev: caps[reg]})
+	vm.set(caps, reg, len(root.capStack)-1)
+	vm.set(caps, 2*idx, start)
+	vm.set(caps, 2*idx+1, end)
+}
+
+// step returns the rune at pos and the signed distance to move past it: the
+// rune after pos when matching forwards, or the rune before it (with a
+// negative width) when matching backwards. The width is 0 at either end.
+func (vm *VM) step(pos int, reverse bool) (rune, int) {
+	if reverse {
+		if pos <= vm.lo {
+			return -1, 0
 		}
+		r, w := vm.input.Context(pos)
+		return r, -w
+	}
+	if pos >= vm.hi {
+		return 0, 0
 	}
+	return vm.input.Step(pos)
 }
 
 // matchClass checks if rune r matches the character class.
@@ -291,6 +635,12 @@ func checkRanges(r rune, ranges []RuneRange) bool {
 		return r >= ranges[0].Lo && r <= ranges[0].Hi
 	}
 
+	// Long lists, such as the Unicode classes, are sorted and disjoint
+	if len(ranges) > 16 {
+		i := sort.Search(len(ranges), func(i int) bool { return ranges[i].Hi >= r })
+		return i < len(ranges) && ranges[i].Lo <= r
+	}
+
 	// General case
 	for _, rng := range ranges {
 		if r >= rng.Lo && r <= rng.Hi {
@@ -316,7 +666,7 @@ func simpleFoldEqual(r1, r2 rune) bool {
 	return false
 }
 
-func (vm *VM) checkAssertion(kind AssertionType, pos int, multiline bool) bool {
+func (vm *VM) checkAssertion(kind AssertionType, pos int, multiline, ucp bool) bool {
 	switch kind {
 	case AssertStartText:
 		if pos == 0 {
@@ -341,9 +691,9 @@ func (vm *VM) checkAssertion(kind AssertionType, pos int, multiline bool) bool {
 		return false
 
 	case AssertWordBoundary:
-		return vm.isWordBoundary(pos)
+		return vm.isWordBoundary(pos, ucp)
 	case AssertNotWordBoundary:
-		return !vm.isWordBoundary(pos)
+		return !vm.isWordBoundary(pos, ucp)
 
 	case AssertStringStart:
 		// \A matches only at absolute start of string
@@ -371,13 +721,17 @@ func (vm *VM) checkAssertion(kind AssertionType, pos int, multiline bool) bool {
 	return true
 }
 
-func (vm *VM) isWordBoundary(pos int) bool {
+func (vm *VM) isWordBoundary(pos int, ucp bool) bool {
 	// Check if we're at a transition between word and non-word characters
 	prevChar, _ := vm.input.Context(pos)
 	currChar, _ := vm.input.Step(pos)
 
-	prevIsWord := isWordChar(prevChar)
-	currIsWord := isWordChar(currChar)
+	isWord := isWordChar
+	if ucp {
+		isWord = isUnicodeWordChar
+	}
+	prevIsWord := isWord(prevChar)
+	currIsWord := isWord(currChar)
 
 	// Boundary exists when exactly one is a word char
 	return prevIsWord != currIsWord
@@ -389,3 +743,12 @@ func isWordChar(r rune) bool {
 		(r >= '0' && r <= '9') ||
 		r == '_'
 }
+
+// isUnicodeWordChar reports whether r is a word character under (*UCP):
+// a letter, number, nonspacing mark or connector punctuation.
+func isUnicodeWordChar(r rune) bool {
+	if r < 0x80 {
+		return isWordChar(r)
+	}
+	return unicode.In(r, unicode.L, unicode.N, unicode.Mn, unicode.Pc)
+}